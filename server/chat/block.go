@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"context"
+
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func isRefused(ctx context.Context, store ChatStore, leftoverID string, userID string) (bool, error) {
+	refused, err := store.IsRefused(ctx, leftoverID, userID)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to check blocked users: %v", err)
+	}
+	return refused, nil
+}
+
+// kickRoom removes uid from the slot or the queue of a room on every replica.
+func (h *hub) kickRoom(ctx context.Context, roomID string, uid string) error {
+	return h.publish(ctx, roomEvent{Kind: eventKick, RoomID: roomID, UserID: uid})
+}
+
+func (s *ChatServer) BlockUser(ctx context.Context, req *BlockUserRequest) (*emptypb.Empty, error) {
+	if err := s.store.Block(ctx, req.UserId, req.BlockedUserId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to block user: %v", err)
+	}
+
+	// the blocked user may be talking or waiting in one of the owner's rooms right now
+	roomIDs, err := s.store.OwnedLeftovers(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query leftovers: %v", err)
+	}
+
+	for _, roomID := range roomIDs {
+		if err := s.hub.kickRoom(ctx, roomID, req.BlockedUserId); err != nil {
+			return nil, err
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) UnblockUser(ctx context.Context, req *BlockUserRequest) (*emptypb.Empty, error) {
+	if err := s.store.Unblock(ctx, req.UserId, req.BlockedUserId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unblock user: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) ListBlocked(ctx context.Context, req *ListBlockedRequest) (*ListBlockedResponse, error) {
+	items, err := s.store.ListBlocked(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query blocked users: %v", err)
+	}
+
+	return &ListBlockedResponse{Items: items}, nil
+}
+
+func (s *ChatServer) BanUser(ctx context.Context, req *BanUserRequest) (*emptypb.Empty, error) {
+	isOwner, err := isUserOwner(ctx, s.store, req.OwnerId, req.LeftoverId)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, status.Errorf(codes.PermissionDenied, "only the owner can ban users")
+	}
+
+	if err := s.store.Ban(ctx, req.LeftoverId, req.UserId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to ban user: %v", err)
+	}
+
+	if err := s.hub.kickRoom(ctx, req.LeftoverId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
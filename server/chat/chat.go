@@ -2,23 +2,39 @@ package chat
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"lovco/server/logging"
+	"lovco/server/pubsub"
+	"lovco/server/webhook"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type DatabaseInterface interface {
-	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
-	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
-	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+// streamBuffer is how many messages can wait for a stream before it is dropped as too slow.
+const streamBuffer = 64
+
+var tracer = otel.Tracer("lovco/server/chat")
+
+// endSpan marks the span as failed when err is set and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
 }
 
 // A room is a limited private chat between a user and a leftover owner.
@@ -27,167 +43,684 @@ type DatabaseInterface interface {
 // Once the user leaves the room, they can no longer see the chat history.
 // Once the leftover owner leaves the room, the room is deleted.
 // Once the user leaves the room, the room stay still.
+//
+// ownerID, guestID and queue are the same on every replica, they only change by applying room events.
+// slots, waiters and held only know about the streams connected to this replica.
+//
+// The events of a room are applied in order by a worker goroutine of its own, started when events
+// are pending, so a room that waits for the store does not hold up the others.
+// A room nobody uses on this replica any more is evicted, it is loaded again from the store when needed.
 type room struct {
-	mu          sync.Mutex // lock for slots and queue
-	slots       map[string]ChatService_JoinChatServer
-	ownerID     string
-	guestID     string
-	queue       []waiter          // queue for users waiting for a slot
-	broadcaster chan *ChatMessage // broadcast channel for messages
-	closed      bool              // if the room is closed
+	mu       sync.Mutex // lock for slots and queue
+	slots    map[string]*waiter
+	ownerID  string
+	guestID  string
+	queue    []string                   // user ids waiting for a slot, in join order
+	waiters  map[string]*waiter         // local streams waiting for their join to be applied
+	held     map[string]*time.Timer     // seats kept for disconnected users until their grace period ends
+	watchers map[chan struct{}]struct{} // queue watchers of this replica, signalled when the queue changes
+	closed   bool                       // if the room is closed
+	logger   *slog.Logger               // the room events do not belong to a call
+
+	ready   chan struct{} // closed once the stored state of the room is loaded
+	pending []roomEvent   // events waiting for the worker
+	working bool          // a worker is applying the pending events
+	evicted bool          // the room was removed from the hub, whoever holds it must get it again
 }
 
 // artificial queue for business logic. Users are waiting for a slot
 // uid is the user id
 // stream is the stream for the user
+// out holds the messages for the stream, only the JoinChat call of the stream sends them
 // ready is a channel that is closed when the user is ready to be added to the slots
+// done is a channel that is closed when the user is removed from their seat
+// err is set before ready or done is closed when the user was refused or removed
 type waiter struct {
 	uid    string
 	stream ChatService_JoinChatServer
+	out    chan *ChatMessage
 	ready  chan struct{}
+	done   chan struct{}
+	err    error
 }
 
-var (
-	rooms   = make(map[string]*room)
-	roomsMu sync.RWMutex
-)
+// hub keeps the rooms of this replica in sync with the other replicas through the broker.
+// Every change to a room is published as a roomEvent and only applied once it comes back
+// from the broker, so all replicas apply the same events in the same order.
+//
+// Rooms are loaded lazily from the store the first time they are used,
+// and the replica that published an event stores the resulting state.
+type hub struct {
+	node      string // identifies this replica as the origin of the events it publishes
+	store     ChatStore
+	webhooks  webhook.Emitter
+	broker    pubsub.Broker
+	opts      Options
+	logger    *slog.Logger
+	rooms     map[string]*room
+	roomsMu   sync.RWMutex
+	draining  chan struct{} // closed when the replica shuts down
+	drainOnce sync.Once
+	stop      context.CancelFunc // ends run
+
+	sendFailures atomic.Uint64 // broadcasts that could not be sent on a stream or were dropped for a slow one
+}
 
-func getRoom(roomID string) *room {
-	roomsMu.RLock()
-	r := rooms[roomID]
-	roomsMu.RUnlock()
-
-	// room is not created, create it
-	roomsMu.Lock()
-	if r == nil {
-		r = &room{
-			slots:       make(map[string]ChatService_JoinChatServer),
-			broadcaster: make(chan *ChatMessage),
-		}
-		rooms[roomID] = r
-		go r.runBroadcaster()
+func newHub(store ChatStore, webhooks webhook.Emitter, broker pubsub.Broker, opts Options) *hub {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
 	}
-	roomsMu.Unlock()
+	return &hub{
+		node:     uuid.NewString(),
+		store:    store,
+		webhooks: webhooks,
+		broker:   broker,
+		opts:     opts,
+		logger:   logger,
+		rooms:    make(map[string]*room),
+		draining: make(chan struct{}),
+	}
+}
 
-	return r
+// drain tells every stream of this replica to go away.
+// Seats and queue positions are not released, the stored rooms keep them for the users
+// reconnecting to another replica or to this one after the restart.
+func (h *hub) drain() {
+	h.drainOnce.Do(func() { close(h.draining) })
 }
 
-func joinRoom(roomID string, uid string, isOwner bool, stream ChatService_JoinChatServer) error {
-	slog.Info("user is trying to join room", "user_id", uid, "leftover_id", roomID, "is_owner", isOwner)
-	// lock room map to prevent race conditions
-	room := getRoom(roomID)
+func (h *hub) isDraining() bool {
+	select {
+	case <-h.draining:
+		return true
+	default:
+		return false
+	}
+}
 
-	// lock room to prevent race conditions
-	room.mu.Lock()
-	if room.closed {
-		// if room is closed unlock, return error
-		room.mu.Unlock()
-		return status.Errorf(codes.Canceled, "chat session is closed")
+// errGoingAway ends the streams of a draining replica, clients should reconnect.
+var errGoingAway = status.Errorf(codes.Unavailable, "server is going away, reconnect")
+
+func (h *hub) lookupRoom(roomID string) *room {
+	h.roomsMu.RLock()
+	defer h.roomsMu.RUnlock()
+	return h.rooms[roomID]
+}
+
+// getRoom returns the room, creating it and loading what was stored before a restart
+// the first time it is used on this replica.
+func (h *hub) getRoom(ctx context.Context, roomID string) (*room, error) {
+	r, created := h.roomEntry(roomID)
+	if created {
+		h.load(ctx, roomID, r)
 	}
+	return r, r.wait(ctx)
+}
 
-	// owner can join room a seat is always available for them
-	if isOwner {
-		slog.Info("user is owner, joining room", "user_id", uid, "leftover_id", roomID)
-		room.ownerID = uid
-		room.slots[uid] = stream
+// existingRoom is like getRoom but does not create a room that was never used.
+func (h *hub) existingRoom(ctx context.Context, roomID string) (*room, error) {
+	if r := h.lookupRoom(roomID); r != nil {
+		return r, r.wait(ctx)
+	}
+
+	snap, err := h.store.LoadRoom(ctx, roomID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load room: %v", err)
+	}
+	if snap == nil {
+		return nil, nil
+	}
+	r, created := h.roomEntry(roomID)
+	if created {
+		h.loaded(roomID, r, snap)
+	}
+	return r, r.wait(ctx)
+}
+
+// roomEntry returns the room, adding an empty one that still has to be loaded when there is none.
+func (h *hub) roomEntry(roomID string) (*room, bool) {
+	if r := h.lookupRoom(roomID); r != nil {
+		return r, false
+	}
+
+	h.roomsMu.Lock()
+	defer h.roomsMu.Unlock()
+	if r := h.rooms[roomID]; r != nil {
+		return r, false
+	}
+	r := &room{
+		slots:    make(map[string]*waiter),
+		waiters:  make(map[string]*waiter),
+		held:     make(map[string]*time.Timer),
+		watchers: make(map[chan struct{}]struct{}),
+		logger:   h.logger,
+		ready:    make(chan struct{}),
+	}
+	h.rooms[roomID] = r
+	return r, true
+}
+
+// load fills a room added by roomEntry with its stored state, the room may be left over from before a restart.
+func (h *hub) load(ctx context.Context, roomID string, r *room) {
+	snap, err := h.store.LoadRoom(ctx, roomID)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to load room, starting fresh", "leftover_id", roomID, "error", err)
+	}
+	h.loaded(roomID, r, snap)
+}
+
+// loaded restores snap into a room added by roomEntry and lets everybody waiting for it go on.
+func (h *hub) loaded(roomID string, r *room, snap *RoomSnapshot) {
+	if snap != nil {
+		r.mu.Lock()
+		h.restore(r, roomID, snap)
+		r.mu.Unlock()
+	}
+	close(r.ready)
+}
+
+// lockRoom returns the room get returns with room.mu held,
+// getting it again when it was evicted before it could be locked.
+func lockRoom(get func() (*room, error)) (*room, error) {
+	for {
+		room, err := get()
+		if err != nil || room == nil {
+			return room, err
+		}
+		room.mu.Lock()
+		if !room.evicted {
+			return room, nil
+		}
 		room.mu.Unlock()
+	}
+}
+
+// evict removes the room from the hub when nothing on this replica uses it any more:
+// no stream, waiter, held seat, queue watcher or pending event.
+func (h *hub) evict(roomID string, room *room) {
+	h.roomsMu.Lock()
+	defer h.roomsMu.Unlock()
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if h.rooms[roomID] != room || room.working || len(room.pending) > 0 ||
+		len(room.slots) > 0 || len(room.waiters) > 0 || len(room.held) > 0 || len(room.watchers) > 0 {
+		return
+	}
+	select {
+	case <-room.ready:
+	default:
+		// still being loaded for somebody
+		return
+	}
+	room.evicted = true
+	delete(h.rooms, roomID)
+}
+
+// wait returns once the room is loaded.
+func (room *room) wait(ctx context.Context) error {
+	select {
+	case <-room.ready:
 		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// joinRoom returns once the user has a seat, the returned waiter stays attached to that seat.
+func (h *hub) joinRoom(ctx context.Context, roomID string, uid string, isOwner bool, stream ChatService_JoinChatServer) (seat *waiter, err error) {
+	ctx, span := tracer.Start(ctx, "chat.join", trace.WithAttributes(
+		attribute.String("leftover.id", roomID),
+		attribute.String("user.id", uid),
+		attribute.Bool("chat.is_owner", isOwner),
+	))
+	defer func() { endSpan(span, err) }()
+
+	logger := logging.FromContext(ctx)
+	logger.Info("user is trying to join room", "is_owner", isOwner)
+	if h.isDraining() {
+		return nil, errGoingAway
 	}
 
-	// if there is a slot available, add to slots and return
-	if room.guestID == "" || room.guestID == uid {
-		slog.Info("user is guest, joining room", "user_id", uid, "leftover_id", roomID)
-		room.guestID = uid
-		room.slots[uid] = stream
+	// lock room to prevent race conditions
+	room, err := lockRoom(func() (*room, error) { return h.getRoom(ctx, roomID) })
+	if err != nil {
+		return nil, err
+	}
+	if room.closed {
+		// if room is closed unlock, return error
 		room.mu.Unlock()
-		return nil
+		return nil, status.Errorf(codes.Canceled, "chat session is closed")
 	}
 
-	// Not enough slots, add to queue
-	queuedWaiter := waiter{
+	// the stream waits until the join comes back from the broker and gets a seat
+	joining := &waiter{
 		uid:    uid,
 		stream: stream,
+		out:    make(chan *ChatMessage, streamBuffer),
 		ready:  make(chan struct{}),
+		done:   make(chan struct{}),
 	}
-
-	room.queue = append(room.queue, queuedWaiter)
-	slog.Info("user is guest, joining queue", "user_id", uid, "leftover_id", roomID)
+	// a newer stream of the same user takes over the queue position, the older one ends
+	room.refuse(uid, status.Errorf(codes.Aborted, "the user joined the chat again from another stream"))
+	room.waiters[uid] = joining
 	room.mu.Unlock()
 
+	if err := h.publish(ctx, roomEvent{Kind: eventJoin, RoomID: roomID, UserID: uid, IsOwner: isOwner}); err != nil {
+		room.mu.Lock()
+		if room.waiters[uid] == joining {
+			delete(room.waiters, uid)
+		}
+		room.mu.Unlock()
+		h.evict(roomID, room)
+		return nil, err
+	}
+
+	var timeout <-chan time.Time
+	if h.opts.MaxQueueWait > 0 {
+		timer := time.NewTimer(h.opts.MaxQueueWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
 	// Wait for a slot to be available
-	<-queuedWaiter.ready
+	_, wait := tracer.Start(ctx, "chat.queue_wait")
+	defer wait.End()
+	select {
+	case <-joining.ready:
+		if joining.err != nil {
+			wait.SetAttributes(attribute.String("chat.queue.outcome", "refused"))
+			return nil, joining.err
+		}
+		wait.SetAttributes(attribute.String("chat.queue.outcome", "seated"))
+		return joining, nil
+	case <-ctx.Done():
+		wait.SetAttributes(attribute.String("chat.queue.outcome", "gave_up"))
+		logger.Info("user gave up waiting, leaving queue")
+		h.abandon(room, roomID, joining)
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case <-timeout:
+		wait.SetAttributes(attribute.String("chat.queue.outcome", "timeout"))
+		logger.Info("user waited too long, leaving queue")
+		h.abandon(room, roomID, joining)
+		return nil, status.Errorf(codes.DeadlineExceeded, "no seat became available within %s", h.opts.MaxQueueWait)
+	case <-h.draining:
+		wait.SetAttributes(attribute.String("chat.queue.outcome", "draining"))
+		// the queue position stays, only this replica forgets the stream
+		room.mu.Lock()
+		if room.waiters[uid] == joining {
+			delete(room.waiters, uid)
+		}
+		room.mu.Unlock()
+		// nothing else sends on the stream of a waiter
+		if err := stream.Send(goingAway(roomID)); err != nil {
+			logger.Info("failed to say goodbye", "error", err)
+		}
+		return nil, errGoingAway
+	}
+}
+
+// abandon takes a waiter whose stream is gone out of the queue, on every replica.
+// Nothing is released when the position went to a newer stream of the same user.
+func (h *hub) abandon(room *room, roomID string, w *waiter) {
+	room.mu.Lock()
+	waiting := room.waiters[w.uid] == w
+	if waiting {
+		delete(room.waiters, w.uid)
+	}
+	// the seat may have been granted in the meantime, the leave frees it again
+	owned := waiting || room.slots[w.uid] == w
+	room.mu.Unlock()
 
-	return nil
+	if owned {
+		h.release(roomID, w.uid)
+	}
+	h.evict(roomID, room)
 }
 
-func leaveRoom(roomID string, uid string) {
-	slog.Info("user is leaving room", "user_id", uid, "leftover_id", roomID)
-	// lock room map to prevent race conditions
-	roomsMu.Lock()
-	room := rooms[roomID]
-	roomsMu.Unlock()
+func (h *hub) leaveRoom(ctx context.Context, roomID string, uid string) error {
+	logging.FromContext(ctx).Info("user is leaving room")
+	return h.publish(ctx, roomEvent{Kind: eventLeave, RoomID: roomID, UserID: uid})
+}
+
+// disconnectRoom is called when the stream of a seated user ends without an explicit leave.
+// The seat is held for the grace period so the same user can reclaim it by joining again,
+// after that the seat is freed like leaveRoom does. ctx is the context of the ended stream.
+func (h *hub) disconnectRoom(ctx context.Context, roomID string, w *waiter, grace time.Duration) {
+	uid := w.uid
+	room := h.lookupRoom(roomID)
+	if room == nil {
+		return
+	}
+	defer h.evict(roomID, room)
 
-	// lock room to prevent race conditions
 	room.mu.Lock()
+	// the user already reconnected with a new stream, nothing to release
+	if current, ok := room.slots[uid]; ok && current != w {
+		room.mu.Unlock()
+		return
+	}
+	delete(room.slots, uid)
+
+	// the replica is going away, the stored room keeps the seat until the user is back
+	if h.isDraining() {
+		room.mu.Unlock()
+		return
+	}
+
+	// the seat was already given away, e.g. the user ended the session on another replica
+	if room.ownerID != uid && room.guestID != uid {
+		room.mu.Unlock()
+		return
+	}
+
+	if grace <= 0 {
+		room.mu.Unlock()
+		h.release(roomID, uid)
+		return
+	}
+
+	logging.FromContext(ctx).Info("user disconnected, holding seat", "grace", grace)
+	h.hold(room, roomID, uid, grace)
+	room.mu.Unlock()
+}
+
+// hold keeps the seat or queue position of a disconnected user for d, then releases it
+// unless the user joined again in the meantime. room.mu must be held by the caller.
+func (h *hub) hold(room *room, roomID string, uid string, d time.Duration) {
+	if timer, ok := room.held[uid]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		room.mu.Lock()
+		// the seat was reclaimed or released in the meantime
+		if room.held[uid] != timer {
+			room.mu.Unlock()
+			return
+		}
+		delete(room.held, uid)
+		room.mu.Unlock()
+
+		h.logger.Info("grace period is over, releasing seat", "user_id", uid, "leftover_id", roomID)
+		h.release(roomID, uid)
+	})
+	room.held[uid] = timer
+}
+
+// release publishes a leave for a stream that is already gone, so there is no caller to report to.
+func (h *hub) release(roomID string, uid string) {
+	logger := h.logger.With("user_id", uid, "leftover_id", roomID)
+	if err := h.leaveRoom(logging.NewContext(context.Background(), logger), roomID, uid); err != nil {
+		logger.Error("failed to release seat", "error", err)
+	}
+}
+
+// join applies a join event, maxQueue of 0 means the queue is unbounded.
+// room.mu must be held by the caller.
+func (room *room) join(roomID string, uid string, isOwner bool, maxQueue int) {
+	// the user is back within the grace period, their seat is still there
+	if timer, ok := room.held[uid]; ok {
+		room.logger.Info("user is reclaiming held seat", "user_id", uid, "leftover_id", roomID)
+		timer.Stop()
+		delete(room.held, uid)
+	}
+
+	switch {
+	// owner can join room a seat is always available for them
+	case isOwner:
+		room.logger.Info("user is owner, joining room", "user_id", uid, "leftover_id", roomID)
+		room.ownerID = uid
+	// if there is a slot available, take it
+	case room.guestID == "" || room.guestID == uid:
+		room.logger.Info("user is guest, joining room", "user_id", uid, "leftover_id", roomID)
+		room.guestID = uid
+	// Not enough slots, add to queue
+	default:
+		if slices.Contains(room.queue, uid) {
+			return
+		}
+		if maxQueue > 0 && len(room.queue) >= maxQueue {
+			room.logger.Info("queue is full, refusing user", "user_id", uid, "leftover_id", roomID, "queued", len(room.queue))
+			room.refuse(uid, status.Errorf(codes.ResourceExhausted, "chat queue is full"))
+			return
+		}
+		room.logger.Info("user is guest, joining queue", "user_id", uid, "leftover_id", roomID)
+		room.queue = append(room.queue, uid)
+		room.notify()
+		return
+	}
+
+	room.seat(uid)
+}
 
+// vacate applies a leave event, frees the seat of uid and hands the guest seat to the next waiter.
+// room.mu must be held by the caller.
+func (room *room) vacate(roomID string, uid string) {
 	// remove user from slots
 	delete(room.slots, uid)
 
+	if timer, ok := room.held[uid]; ok {
+		timer.Stop()
+		delete(room.held, uid)
+	}
+
+	// the user may have given up waiting
+	room.queue = slices.DeleteFunc(room.queue, func(queued string) bool { return queued == uid })
+
+	if room.ownerID == uid {
+		room.ownerID = ""
+	}
+
 	// if user is guest, remove them from room definition
 	if room.guestID == uid {
-		slog.Info("user is guest, removing from room definition", "user_id", uid, "leftover_id", roomID)
+		room.logger.Info("user is guest, removing from room definition", "user_id", uid, "leftover_id", roomID)
 		room.guestID = ""
 	}
 
-	// if there is a queue, remove the first user from the queue and add them to the slots
-	if len(room.queue) > 0 {
-		slog.Info("another user is joining room", "user_id", uid, "leftover_id", roomID)
-		nextWaiter := room.queue[0]
+	// if the guest seat is free and there is a queue, remove the first user from the queue and add them to the slots
+	if room.guestID == "" && len(room.queue) > 0 {
+		next := room.queue[0]
+		room.logger.Info("another user is joining room", "user_id", next, "leftover_id", roomID)
 		room.queue = room.queue[1:]
-		room.guestID = nextWaiter.uid
-		// keep the slot
-		room.slots[nextWaiter.uid] = nextWaiter.stream
-		close(nextWaiter.ready)
+		room.guestID = next
+		room.seat(next)
 	}
 
-	room.mu.Unlock()
+	room.notify()
 }
 
-func (room *room) runBroadcaster() {
-	for msg := range room.broadcaster {
-		room.mu.Lock()
-		for uid, stream := range room.slots {
-			if err := stream.Send(msg); err != nil {
-				delete(room.slots, uid)
-			}
+// seat moves a local waiter into the slots once their seat is granted.
+// Users connected to another replica have no waiter here.
+// room.mu must be held by the caller.
+func (room *room) seat(uid string) {
+	w, ok := room.waiters[uid]
+	if !ok {
+		return
+	}
+	delete(room.waiters, uid)
+	// keep the slot
+	room.slots[uid] = w
+	close(w.ready)
+}
+
+// refuse wakes a local waiter up with err instead of a seat.
+// room.mu must be held by the caller.
+func (room *room) refuse(uid string, err error) {
+	w, ok := room.waiters[uid]
+	if !ok {
+		return
+	}
+	delete(room.waiters, uid)
+	w.err = err
+	close(w.ready)
+}
+
+// kick removes uid from the room for good: a local stream waiting in the queue is refused
+// and a local seated stream is ended with err.
+// room.mu must be held by the caller.
+func (room *room) kick(roomID string, uid string, err error) {
+	room.refuse(uid, err)
+	// a stream dropped for being slow is ending already
+	if w, ok := room.slots[uid]; ok && w.err == nil {
+		w.err = err
+		close(w.done)
+	}
+	room.logger.Info("user is removed from room", "user_id", uid, "leftover_id", roomID)
+	room.vacate(roomID, uid)
+}
+
+// notify tells the queue watchers of this replica that positions may have changed.
+// room.mu must be held by the caller.
+func (room *room) notify() {
+	for ch := range room.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// the watcher has a pending update already
 		}
-		room.mu.Unlock()
 	}
 }
 
-func isUserOwner(ctx context.Context, db DatabaseInterface, userID string, leftoverID string) (bool, error) {
-	query := `
-		SELECT owner_id 
-		FROM leftover
-		WHERE id = $1
-	`
-	row := db.QueryRow(ctx, query, leftoverID)
-	var ownerID string
-	err := row.Scan(&ownerID)
+// isSeated reports whether uid is the owner or the active guest of the room.
+func (room *room) isSeated(uid string) bool {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	return uid != "" && (room.ownerID == uid || room.guestID == uid)
+}
+
+func heartbeat(roomID string) *ChatMessage {
+	return &ChatMessage{
+		LeftoverId: roomID,
+		Event:      ChatEvent_CHAT_EVENT_HEARTBEAT,
+		CreatedAt:  timestamppb.Now(),
+	}
+}
+
+func goingAway(roomID string) *ChatMessage {
+	return &ChatMessage{
+		LeftoverId: roomID,
+		Event:      ChatEvent_CHAT_EVENT_GOING_AWAY,
+		CreatedAt:  timestamppb.Now(),
+	}
+}
+
+// broadcast sends msg to every stream in the room, on every replica.
+// msg must be stored first, the replicas send the stored message.
+func (h *hub) broadcast(ctx context.Context, msg *ChatMessage) (err error) {
+	ctx, span := tracer.Start(ctx, "chat.broadcast", trace.WithAttributes(
+		attribute.String("leftover.id", msg.LeftoverId),
+		attribute.String("chat.message.id", msg.Id),
+		attribute.String("chat.event", msg.Event.String()),
+	))
+	defer func() { endSpan(span, err) }()
+
+	return h.publish(ctx, roomEvent{Kind: eventMessage, RoomID: msg.LeftoverId, MessageID: msg.Id, MessageEvent: msg.Event})
+}
+
+// deliver queues msg for every stream of the room on this replica without waiting for them.
+// A stream whose queue is full is not keeping up, it is ended so the client reconnects and
+// gets the history again. It keeps its slot until disconnectRoom holds the seat like after any other disconnect.
+// It returns how many streams were dropped. room.mu must be held by the caller.
+func (room *room) deliver(msg *ChatMessage) int {
+	dropped := 0
+	for _, w := range room.slots {
+		if w.err != nil {
+			continue
+		}
+		select {
+		case w.out <- msg:
+		default:
+			w.err = status.Errorf(codes.ResourceExhausted, "chat stream is not keeping up, reconnect")
+			close(w.done)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+func leftoverOwner(ctx context.Context, store ChatStore, leftoverID string) (string, error) {
+	ownerID, err := store.LeftoverOwner(ctx, leftoverID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return "", status.Errorf(codes.NotFound, "leftover not found")
+		}
+		return "", status.Errorf(codes.Internal, "failed to get leftover owner: %v", err)
+	}
+	return ownerID, nil
+}
+
+func isUserOwner(ctx context.Context, store ChatStore, userID string, leftoverID string) (bool, error) {
+	ownerID, err := leftoverOwner(ctx, store, leftoverID)
 	if err != nil {
-		return false, status.Errorf(codes.Internal, "failed to get leftover owner: %v", err)
+		return false, err
 	}
 	return ownerID == userID, nil
 }
 
+// Options tunes the chat behaviour.
+// EditWindow is how long after sending a message its author can still edit or delete it.
+// ReconnectGrace is how long a seat is kept for a user whose stream dropped.
+// RestoreWindow is how long seats and queue positions stored before a restart are kept for returning users.
+// MaxQueueLength and MaxQueueWait bound the queue of a room, 0 means no limit.
+// They must be the same on every replica since each replica applies them on its own.
+// Admins are the user ids allowed to export any transcript.
+// UserSendRate and RoomSendRate are the messages per second a user or a room may send on average,
+// with bursts of UserSendBurst and RoomSendBurst, 0 means no limit.
+// A user sending the same message SpamRepeats times or SpamLinks links within SpamWindow is muted for MuteDuration.
+// HeartbeatInterval is how long a chat stream may stay quiet before it gets a heartbeat event, 0 disables them.
+type Options struct {
+	EditWindow     time.Duration
+	ReconnectGrace time.Duration
+	RestoreWindow  time.Duration
+	MaxQueueLength int
+	MaxQueueWait   time.Duration
+	Admins         []string
+	UserSendRate   float64
+	UserSendBurst  int
+	RoomSendRate   float64
+	RoomSendBurst  int
+	SpamWindow     time.Duration
+	SpamRepeats    int
+	SpamLinks      int
+	MuteDuration   time.Duration
+
+	HeartbeatInterval time.Duration
+
+	// Logger logs what does not belong to a call, like the room events coming from the broker,
+	// slog.Default when nil. Calls log with the logger in their context.
+	Logger *slog.Logger
+}
+
 type ChatServer struct {
 	UnimplementedChatServiceServer
-	db DatabaseInterface
+	store ChatStore
+	opts  Options
+	hub   *hub
+	guard *guard
 }
 
-func NewChatServer(db *pgxpool.Pool) *ChatServer {
+// NewChatServer creates the chat service. The broker carries room events between replicas,
+// use pubsub.NewMemoryBroker when running a single node.
+func NewChatServer(store ChatStore, webhooks webhook.Emitter, broker pubsub.Broker, opts Options) *ChatServer {
+	h := newHub(store, webhooks, broker, opts)
+	ctx, stop := context.WithCancel(context.Background())
+	h.stop = stop
+	// subscribed before the server takes calls, so the first joins are not missed
+	events, err := broker.Subscribe(ctx, eventsTopic)
+	if err != nil {
+		h.logger.Error("failed to subscribe to room events", "error", err)
+	} else {
+		go h.run(events)
+	}
+
 	return &ChatServer{
-		db: db,
+		store: store,
+		opts:  opts,
+		hub:   h,
+		guard: newGuard(opts),
 	}
 }
 
@@ -196,26 +729,131 @@ func (s *ChatServer) JoinChat(req *JoinChatRequest, stream ChatService_JoinChatS
 	lid := req.LeftoverId
 	ctx := stream.Context()
 
-	isOwner, err := isUserOwner(ctx, s.db, uid, lid)
+	isOwner, err := isUserOwner(ctx, s.store, uid, lid)
 	if err != nil {
 		return err
 	}
 
+	if !isOwner {
+		refused, err := isRefused(ctx, s.store, lid, uid)
+		if err != nil {
+			return err
+		}
+		if refused {
+			return status.Errorf(codes.PermissionDenied, "user is not allowed in the chat room")
+		}
+	}
+
 	// try to join room
-	err = joinRoom(lid, uid, isOwner, stream)
+	seat, err := s.hub.joinRoom(ctx, lid, uid, isOwner, stream)
+	if err != nil {
+		return err
+	}
+	defer s.hub.disconnectRoom(ctx, lid, seat, s.opts.ReconnectGrace)
+
+	history, err := getHistory(ctx, s.store, lid)
+	if err != nil {
+		return err
+	}
+	replayed, err := sendHistory(history, stream)
 	if err != nil {
 		return err
 	}
-	defer leaveRoom(lid, uid)
+	if err := s.store.MarkHistoryDelivered(ctx, lid, uid, time.Now().UTC()); err != nil {
+		logging.FromContext(ctx).Error("failed to mark chat history as delivered", "error", err)
+	}
+
+	var (
+		ticker *time.Ticker
+		beat   <-chan time.Time
+	)
+	if s.opts.HeartbeatInterval > 0 {
+		ticker = time.NewTicker(s.opts.HeartbeatInterval)
+		defer ticker.Stop()
+		beat = ticker.C
+	}
 
+	// this is the only place sending on the stream once the user has a seat
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		default:
-			time.Sleep(50 * time.Millisecond)
+		case <-seat.done:
+			return seat.err
+		case msg := <-seat.out:
+			if _, ok := replayed[msg.Id]; ok && msg.Event == ChatEvent_CHAT_EVENT_MESSAGE {
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				s.hub.sendFailures.Add(1)
+				return err
+			}
+			// the stream is not idle, the next heartbeat is due an interval after this event
+			if ticker != nil {
+				ticker.Reset(s.opts.HeartbeatInterval)
+			}
+			if msg.Event == ChatEvent_CHAT_EVENT_MESSAGE && msg.UserId != uid {
+				go s.hub.markDelivered(msg)
+			}
+		case <-beat:
+			// a failing send means the peer is gone even if the transport did not notice yet
+			if err := stream.Send(heartbeat(lid)); err != nil {
+				logging.FromContext(ctx).Info("heartbeat failed, dropping stream", "error", err)
+				return err
+			}
+		case <-s.hub.draining:
+			if err := stream.Send(goingAway(lid)); err != nil {
+				logging.FromContext(ctx).Info("failed to say goodbye", "error", err)
+			}
+			return errGoingAway
+		}
+	}
+}
+
+// Stats is a snapshot of the chat rooms known to this replica. Rooms counts the rooms
+// somebody is seated or queued in. Rooms and Queued are the same on every replica that loaded them,
+// SendFailures only counts the streams of this replica since it started.
+type Stats struct {
+	Rooms        int
+	Queued       int
+	SendFailures uint64
+}
+
+func (s *ChatServer) Stats() Stats {
+	return s.hub.stats()
+}
+
+func (h *hub) stats() Stats {
+	h.roomsMu.RLock()
+	rooms := make([]*room, 0, len(h.rooms))
+	for _, r := range h.rooms {
+		rooms = append(rooms, r)
+	}
+	h.roomsMu.RUnlock()
+
+	st := Stats{SendFailures: h.sendFailures.Load()}
+	for _, r := range rooms {
+		r.mu.Lock()
+		if r.ownerID != "" || r.guestID != "" || len(r.queue) > 0 {
+			st.Rooms++
 		}
+		st.Queued += len(r.queue)
+		r.mu.Unlock()
 	}
+	return st
+}
+
+// Drain ends every chat and queue stream of this server with a going away event,
+// so clients reconnect to another replica or to this one after the restart.
+// New streams are refused from then on. It does not wait for the streams to end.
+func (s *ChatServer) Drain() {
+	s.hub.logger.Info("draining chat streams")
+	s.hub.drain()
+}
+
+// Stop unsubscribes the server from the room events, call it once the streams are gone.
+func (s *ChatServer) Stop() {
+	s.hub.stop()
 }
 
 func (s *ChatServer) WatchChatQueue(req *JoinChatRequest, stream ChatService_WatchChatQueueServer) error {
@@ -226,84 +864,128 @@ func (s *ChatServer) WatchChatQueue(req *JoinChatRequest, stream ChatService_Wat
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
+	// a room change sends the update right away instead of waiting for the next tick
+	// a user coming back after a restart should see their old position
+	room, err := lockRoom(func() (*room, error) { return s.hub.existingRoom(ctx, lid) })
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		return status.Errorf(codes.NotFound, "chat room not found")
+	}
+	changed := make(chan struct{}, 1)
+	room.watchers[changed] = struct{}{}
+	room.mu.Unlock()
+	defer func() {
+		room.mu.Lock()
+		delete(room.watchers, changed)
+		room.mu.Unlock()
+		s.hub.evict(lid, room)
+	}()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-ticker.C:
-			var queuedCount int
-			var position int32 = -1
-
-			roomsMu.RLock()
-			room := rooms[lid]
-			roomsMu.RUnlock()
-
-			if room != nil {
-				room.mu.Lock()
-				if room.closed {
-					room.mu.Unlock()
-					return status.Errorf(codes.Canceled, "chat session is closed")
-				}
-
-				queuedCount = len(room.queue)
-				for i, w := range room.queue {
-					if w.uid == uid {
-						position = int32(i + 1)
-						break
-					}
-				}
-
-				if position == -1 {
-					if _, ok := room.slots[uid]; ok {
-						position = 0
-					}
-				}
-				room.mu.Unlock()
-			} else {
-				queuedCount = 0
-				position = -1
+		case <-s.hub.draining:
+			if err := stream.Send(&QueueResponse{GoingAway: true}); err != nil {
+				logging.FromContext(ctx).Info("failed to say goodbye", "error", err)
 			}
+			return errGoingAway
+		case <-changed:
+		case <-ticker.C:
+		}
 
-			if err := stream.Send(&QueueResponse{
-				QueuedCount: int32(queuedCount),
-				Position:    position,
-			}); err != nil {
-				return err
-			}
+		var position int32 = -1
+
+		room.mu.Lock()
+		if room.closed {
+			room.mu.Unlock()
+			return status.Errorf(codes.Canceled, "chat session is closed")
+		}
+
+		queuedCount := len(room.queue)
+		if i := slices.Index(room.queue, uid); i >= 0 {
+			position = int32(i + 1)
+		}
+
+		if position == -1 && (room.ownerID == uid || room.guestID == uid) {
+			position = 0
+		}
+		room.mu.Unlock()
+
+		if err := stream.Send(&QueueResponse{
+			QueuedCount: int32(queuedCount),
+			Position:    position,
+		}); err != nil {
+			return err
 		}
 	}
 }
 
 func (s *ChatServer) SendMessage(ctx context.Context, req *ChatMessageRequest) (*emptypb.Empty, error) {
-	roomsMu.RLock()
-	room := rooms[req.LeftoverId]
-	roomsMu.RUnlock()
-	if room != nil {
-		room.broadcaster <- &ChatMessage{
-			LeftoverId: req.LeftoverId,
-			UserId:     req.UserId,
-			Message:    req.Message,
-			Image:      req.Image,
-			CreatedAt:  timestamppb.Now(),
-		}
+	room, err := s.hub.existingRoom(ctx, req.LeftoverId)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, status.Errorf(codes.NotFound, "chat room not found")
+	}
+
+	// only the owner and the active guest can talk, queued users are still waiting
+	if !room.isSeated(req.UserId) {
+		return nil, status.Errorf(codes.PermissionDenied, "user is not in the chat room")
+	}
+
+	refused, err := isRefused(ctx, s.store, req.LeftoverId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if refused {
+		return nil, status.Errorf(codes.PermissionDenied, "user is not allowed in the chat room")
+	}
+
+	if err := s.guard.check(req.LeftoverId, req.UserId, req.Message); err != nil {
+		return nil, err
+	}
+
+	payload, err := requestPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &ChatMessage{
+		LeftoverId: req.LeftoverId,
+		UserId:     req.UserId,
+		Message:    req.Message,
+		Image:      req.Image,
+		Payload:    payload,
+	}
+	if err := s.storeMessage(ctx, msg); err != nil {
+		return nil, err
+	}
+	if err := s.hub.broadcast(ctx, msg); err != nil {
+		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
 func (s *ChatServer) EndChatSession(ctx context.Context, req *EndChatRequest) (*emptypb.Empty, error) {
-	isOwner, err := isUserOwner(ctx, s.db, req.UserId, req.LeftoverId)
+	isOwner, err := isUserOwner(ctx, s.store, req.UserId, req.LeftoverId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get leftover owner: %v", err)
 	}
 
 	if !isOwner {
-		slog.Info("user is not owner, leaving room", "user_id", req.UserId, "leftover_id", req.LeftoverId)
-		leaveRoom(req.LeftoverId, req.UserId)
+		logging.FromContext(ctx).Info("user is not owner, leaving room")
+		if err := s.hub.leaveRoom(ctx, req.LeftoverId, req.UserId); err != nil {
+			return nil, err
+		}
 		return &emptypb.Empty{}, nil
 	}
 
-	slog.Info("user is owner, ending chat session", "user_id", req.UserId, "leftover_id", req.LeftoverId)
+	logging.FromContext(ctx).Info("user is owner, ending chat session")
 
 	return &emptypb.Empty{}, nil
 }
@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	leftover "lovco/server/leftover"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,34 +24,1032 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ChatEvent tells clients how to apply a ChatMessage to their view.
+type ChatEvent int32
+
+const (
+	ChatEvent_CHAT_EVENT_MESSAGE ChatEvent = 0
+	ChatEvent_CHAT_EVENT_EDITED  ChatEvent = 1
+	ChatEvent_CHAT_EVENT_DELETED ChatEvent = 2
+	// the server changed an existing message, e.g. a pickup proposal was answered
+	ChatEvent_CHAT_EVENT_UPDATED ChatEvent = 3
+	// keeps idle streams alive, only leftover_id and created_at are set and clients drop it
+	ChatEvent_CHAT_EVENT_HEARTBEAT ChatEvent = 4
+	// the server is shutting down and closes the stream, clients reconnect to keep their seat
+	ChatEvent_CHAT_EVENT_GOING_AWAY ChatEvent = 5
+)
+
+// Enum value maps for ChatEvent.
+var (
+	ChatEvent_name = map[int32]string{
+		0: "CHAT_EVENT_MESSAGE",
+		1: "CHAT_EVENT_EDITED",
+		2: "CHAT_EVENT_DELETED",
+		3: "CHAT_EVENT_UPDATED",
+		4: "CHAT_EVENT_HEARTBEAT",
+		5: "CHAT_EVENT_GOING_AWAY",
+	}
+	ChatEvent_value = map[string]int32{
+		"CHAT_EVENT_MESSAGE":    0,
+		"CHAT_EVENT_EDITED":     1,
+		"CHAT_EVENT_DELETED":    2,
+		"CHAT_EVENT_UPDATED":    3,
+		"CHAT_EVENT_HEARTBEAT":  4,
+		"CHAT_EVENT_GOING_AWAY": 5,
+	}
+)
+
+func (x ChatEvent) Enum() *ChatEvent {
+	p := new(ChatEvent)
+	*p = x
+	return p
+}
+
+func (x ChatEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_proto_enumTypes[0].Descriptor()
+}
+
+func (ChatEvent) Type() protoreflect.EnumType {
+	return &file_chat_proto_enumTypes[0]
+}
+
+func (x ChatEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatEvent.Descriptor instead.
+func (ChatEvent) EnumDescriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{0}
+}
+
+type PickupStatus int32
+
+const (
+	PickupStatus_PICKUP_STATUS_PENDING  PickupStatus = 0
+	PickupStatus_PICKUP_STATUS_ACCEPTED PickupStatus = 1
+	PickupStatus_PICKUP_STATUS_DECLINED PickupStatus = 2
+)
+
+// Enum value maps for PickupStatus.
+var (
+	PickupStatus_name = map[int32]string{
+		0: "PICKUP_STATUS_PENDING",
+		1: "PICKUP_STATUS_ACCEPTED",
+		2: "PICKUP_STATUS_DECLINED",
+	}
+	PickupStatus_value = map[string]int32{
+		"PICKUP_STATUS_PENDING":  0,
+		"PICKUP_STATUS_ACCEPTED": 1,
+		"PICKUP_STATUS_DECLINED": 2,
+	}
+)
+
+func (x PickupStatus) Enum() *PickupStatus {
+	p := new(PickupStatus)
+	*p = x
+	return p
+}
+
+func (x PickupStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PickupStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_proto_enumTypes[1].Descriptor()
+}
+
+func (PickupStatus) Type() protoreflect.EnumType {
+	return &file_chat_proto_enumTypes[1]
+}
+
+func (x PickupStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PickupStatus.Descriptor instead.
+func (PickupStatus) EnumDescriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{1}
+}
+
+type TranscriptFormat int32
+
+const (
+	TranscriptFormat_TRANSCRIPT_FORMAT_JSON_LINES TranscriptFormat = 0
+	TranscriptFormat_TRANSCRIPT_FORMAT_TEXT       TranscriptFormat = 1
+)
+
+// Enum value maps for TranscriptFormat.
+var (
+	TranscriptFormat_name = map[int32]string{
+		0: "TRANSCRIPT_FORMAT_JSON_LINES",
+		1: "TRANSCRIPT_FORMAT_TEXT",
+	}
+	TranscriptFormat_value = map[string]int32{
+		"TRANSCRIPT_FORMAT_JSON_LINES": 0,
+		"TRANSCRIPT_FORMAT_TEXT":       1,
+	}
+)
+
+func (x TranscriptFormat) Enum() *TranscriptFormat {
+	p := new(TranscriptFormat)
+	*p = x
+	return p
+}
+
+func (x TranscriptFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TranscriptFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_proto_enumTypes[2].Descriptor()
+}
+
+func (TranscriptFormat) Type() protoreflect.EnumType {
+	return &file_chat_proto_enumTypes[2]
+}
+
+func (x TranscriptFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TranscriptFormat.Descriptor instead.
+func (TranscriptFormat) EnumDescriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{2}
+}
+
+type LocationPin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Point         *leftover.Point        `protobuf:"bytes,1,opt,name=point,proto3" json:"point,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocationPin) Reset() {
+	*x = LocationPin{}
+	mi := &file_chat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationPin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationPin) ProtoMessage() {}
+
+func (x *LocationPin) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationPin.ProtoReflect.Descriptor instead.
+func (*LocationPin) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LocationPin) GetPoint() *leftover.Point {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+func (x *LocationPin) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// PickupProposal suggests a time window for the handover, the other side accepts or declines it.
+type PickupProposal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Status        PickupStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=PickupStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupProposal) Reset() {
+	*x = PickupProposal{}
+	mi := &file_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupProposal) ProtoMessage() {}
+
+func (x *PickupProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupProposal.ProtoReflect.Descriptor instead.
+func (*PickupProposal) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PickupProposal) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *PickupProposal) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *PickupProposal) GetStatus() PickupStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PickupStatus_PICKUP_STATUS_PENDING
+}
+
+// SystemNotice is written by the server, clients cannot send it.
+type SystemNotice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemNotice) Reset() {
+	*x = SystemNotice{}
+	mi := &file_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemNotice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemNotice) ProtoMessage() {}
+
+func (x *SystemNotice) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemNotice.ProtoReflect.Descriptor instead.
+func (*SystemNotice) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SystemNotice) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
 type ChatMessage struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LeftoverId string                 `protobuf:"bytes,2,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	UserId     string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Message    string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Image      string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Edited     bool                   `protobuf:"varint,7,opt,name=edited,proto3" json:"edited,omitempty"`
+	Deleted    bool                   `protobuf:"varint,8,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Event      ChatEvent              `protobuf:"varint,10,opt,name=event,proto3,enum=ChatEvent" json:"event,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ChatMessage_Location
+	//	*ChatMessage_Pickup
+	//	*ChatMessage_Notice
+	Payload       isChatMessage_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChatMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetLeftoverId() string {
+	if x != nil {
+		return x.LeftoverId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetEdited() bool {
+	if x != nil {
+		return x.Edited
+	}
+	return false
+}
+
+func (x *ChatMessage) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *ChatMessage) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetEvent() ChatEvent {
+	if x != nil {
+		return x.Event
+	}
+	return ChatEvent_CHAT_EVENT_MESSAGE
+}
+
+func (x *ChatMessage) GetPayload() isChatMessage_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetLocation() *LocationPin {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessage_Location); ok {
+			return x.Location
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetPickup() *PickupProposal {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessage_Pickup); ok {
+			return x.Pickup
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetNotice() *SystemNotice {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessage_Notice); ok {
+			return x.Notice
+		}
+	}
+	return nil
+}
+
+type isChatMessage_Payload interface {
+	isChatMessage_Payload()
+}
+
+type ChatMessage_Location struct {
+	Location *LocationPin `protobuf:"bytes,11,opt,name=location,proto3,oneof"`
+}
+
+type ChatMessage_Pickup struct {
+	Pickup *PickupProposal `protobuf:"bytes,12,opt,name=pickup,proto3,oneof"`
+}
+
+type ChatMessage_Notice struct {
+	Notice *SystemNotice `protobuf:"bytes,13,opt,name=notice,proto3,oneof"`
+}
+
+func (*ChatMessage_Location) isChatMessage_Payload() {}
+
+func (*ChatMessage_Pickup) isChatMessage_Payload() {}
+
+func (*ChatMessage_Notice) isChatMessage_Payload() {}
+
+type ChatMessageRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	LeftoverId string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	UserId     string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Message    string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Image      string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ChatMessageRequest_Location
+	//	*ChatMessageRequest_Pickup
+	Payload       isChatMessageRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessageRequest) Reset() {
+	*x = ChatMessageRequest{}
+	mi := &file_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessageRequest) ProtoMessage() {}
+
+func (x *ChatMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessageRequest.ProtoReflect.Descriptor instead.
+func (*ChatMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChatMessageRequest) GetLeftoverId() string {
+	if x != nil {
+		return x.LeftoverId
+	}
+	return ""
+}
+
+func (x *ChatMessageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChatMessageRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ChatMessageRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ChatMessageRequest) GetPayload() isChatMessageRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChatMessageRequest) GetLocation() *LocationPin {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessageRequest_Location); ok {
+			return x.Location
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessageRequest) GetPickup() *PickupProposal {
+	if x != nil {
+		if x, ok := x.Payload.(*ChatMessageRequest_Pickup); ok {
+			return x.Pickup
+		}
+	}
+	return nil
+}
+
+type isChatMessageRequest_Payload interface {
+	isChatMessageRequest_Payload()
+}
+
+type ChatMessageRequest_Location struct {
+	Location *LocationPin `protobuf:"bytes,5,opt,name=location,proto3,oneof"`
+}
+
+type ChatMessageRequest_Pickup struct {
+	Pickup *PickupProposal `protobuf:"bytes,6,opt,name=pickup,proto3,oneof"`
+}
+
+func (*ChatMessageRequest_Location) isChatMessageRequest_Payload() {}
+
+func (*ChatMessageRequest_Pickup) isChatMessageRequest_Payload() {}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Image         string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EditMessageRequest) GetLeftoverId() string {
+	if x != nil {
+		return x.LeftoverId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteMessageRequest) GetLeftoverId() string {
+	if x != nil {
+		return x.LeftoverId
+	}
+	return ""
+}
+
+func (x *DeleteMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DeleteMessageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EndChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndChatRequest) Reset() {
+	*x = EndChatRequest{}
+	mi := &file_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndChatRequest) ProtoMessage() {}
+
+func (x *EndChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndChatRequest.ProtoReflect.Descriptor instead.
+func (*EndChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EndChatRequest) GetLeftoverId() string {
+	if x != nil {
+		return x.LeftoverId
+	}
+	return ""
+}
+
+func (x *EndChatRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type JoinChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinChatRequest) Reset() {
+	*x = JoinChatRequest{}
+	mi := &file_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinChatRequest) ProtoMessage() {}
+
+func (x *JoinChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinChatRequest.ProtoReflect.Descriptor instead.
+func (*JoinChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JoinChatRequest) GetLeftoverId() string {
+	if x != nil {
+		return x.LeftoverId
+	}
+	return ""
+}
+
+func (x *JoinChatRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type QueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QueuedCount   int32                  `protobuf:"varint,1,opt,name=queued_count,json=queuedCount,proto3" json:"queued_count,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	GoingAway     bool                   `protobuf:"varint,3,opt,name=going_away,json=goingAway,proto3" json:"going_away,omitempty"` // the server is shutting down, reconnect to keep watching
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueResponse) Reset() {
+	*x = QueueResponse{}
+	mi := &file_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueResponse) ProtoMessage() {}
+
+func (x *QueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueResponse.ProtoReflect.Descriptor instead.
+func (*QueueResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QueueResponse) GetQueuedCount() int32 {
+	if x != nil {
+		return x.QueuedCount
+	}
+	return 0
+}
+
+func (x *QueueResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *QueueResponse) GetGoingAway() bool {
+	if x != nil {
+		return x.GoingAway
+	}
+	return false
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BlockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type ListBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListBlockedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BlockedUser struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	LeftoverId    string                 `protobuf:"bytes,2,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	Image         string                 `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
-	IsSeen        bool                   `protobuf:"varint,6,opt,name=is_seen,json=isSeen,proto3" json:"is_seen,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatMessage) Reset() {
-	*x = ChatMessage{}
-	mi := &file_chat_proto_msgTypes[0]
+func (x *BlockedUser) Reset() {
+	*x = BlockedUser{}
+	mi := &file_chat_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatMessage) String() string {
+func (x *BlockedUser) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatMessage) ProtoMessage() {}
+func (*BlockedUser) ProtoMessage() {}
 
-func (x *ChatMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[0]
+func (x *BlockedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,85 +1060,155 @@ func (x *ChatMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
-func (*ChatMessage) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use BlockedUser.ProtoReflect.Descriptor instead.
+func (*BlockedUser) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ChatMessage) GetId() string {
+func (x *BlockedUser) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetLeftoverId() string {
+func (x *BlockedUser) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.LeftoverId
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *ChatMessage) GetUserId() string {
+type ListBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*BlockedUser         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[13]
 	if x != nil {
-		return x.UserId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ChatMessage) GetMessage() string {
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListBlockedResponse) GetItems() []*BlockedUser {
 	if x != nil {
-		return x.Message
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-func (x *ChatMessage) GetImage() string {
+// BanUserRequest bans user_id from the chat of a single leftover, only its owner can do that.
+type BanUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanUserRequest) Reset() {
+	*x = BanUserRequest{}
+	mi := &file_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanUserRequest) ProtoMessage() {}
+
+func (x *BanUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[14]
 	if x != nil {
-		return x.Image
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanUserRequest.ProtoReflect.Descriptor instead.
+func (*BanUserRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BanUserRequest) GetLeftoverId() string {
+	if x != nil {
+		return x.LeftoverId
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetIsSeen() bool {
+func (x *BanUserRequest) GetOwnerId() string {
 	if x != nil {
-		return x.IsSeen
+		return x.OwnerId
 	}
-	return false
+	return ""
 }
 
-func (x *ChatMessage) GetCreatedAt() *timestamppb.Timestamp {
+func (x *BanUserRequest) GetUserId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type ChatMessageRequest struct {
+type PickupResponseRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,4,opt,name=accept,proto3" json:"accept,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatMessageRequest) Reset() {
-	*x = ChatMessageRequest{}
-	mi := &file_chat_proto_msgTypes[1]
+func (x *PickupResponseRequest) Reset() {
+	*x = PickupResponseRequest{}
+	mi := &file_chat_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatMessageRequest) String() string {
+func (x *PickupResponseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatMessageRequest) ProtoMessage() {}
+func (*PickupResponseRequest) ProtoMessage() {}
 
-func (x *ChatMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[1]
+func (x *PickupResponseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -150,40 +1219,40 @@ func (x *ChatMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatMessageRequest.ProtoReflect.Descriptor instead.
-func (*ChatMessageRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use PickupResponseRequest.ProtoReflect.Descriptor instead.
+func (*PickupResponseRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ChatMessageRequest) GetLeftoverId() string {
+func (x *PickupResponseRequest) GetLeftoverId() string {
 	if x != nil {
 		return x.LeftoverId
 	}
 	return ""
 }
 
-func (x *ChatMessageRequest) GetUserId() string {
+func (x *PickupResponseRequest) GetMessageId() string {
 	if x != nil {
-		return x.UserId
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *ChatMessageRequest) GetMessage() string {
+func (x *PickupResponseRequest) GetUserId() string {
 	if x != nil {
-		return x.Message
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *ChatMessageRequest) GetImage() string {
+func (x *PickupResponseRequest) GetAccept() bool {
 	if x != nil {
-		return x.Image
+		return x.Accept
 	}
-	return ""
+	return false
 }
 
-type EndChatRequest struct {
+type AgreedPickupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -191,21 +1260,21 @@ type EndChatRequest struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EndChatRequest) Reset() {
-	*x = EndChatRequest{}
-	mi := &file_chat_proto_msgTypes[2]
+func (x *AgreedPickupRequest) Reset() {
+	*x = AgreedPickupRequest{}
+	mi := &file_chat_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EndChatRequest) String() string {
+func (x *AgreedPickupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EndChatRequest) ProtoMessage() {}
+func (*AgreedPickupRequest) ProtoMessage() {}
 
-func (x *EndChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[2]
+func (x *AgreedPickupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,48 +1285,53 @@ func (x *EndChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EndChatRequest.ProtoReflect.Descriptor instead.
-func (*EndChatRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use AgreedPickupRequest.ProtoReflect.Descriptor instead.
+func (*AgreedPickupRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *EndChatRequest) GetLeftoverId() string {
+func (x *AgreedPickupRequest) GetLeftoverId() string {
 	if x != nil {
 		return x.LeftoverId
 	}
 	return ""
 }
 
-func (x *EndChatRequest) GetUserId() string {
+func (x *AgreedPickupRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type JoinChatRequest struct {
+type AgreedPickup struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ProposerId    string                 `protobuf:"bytes,3,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`
+	AcceptedBy    string                 `protobuf:"bytes,4,opt,name=accepted_by,json=acceptedBy,proto3" json:"accepted_by,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	AcceptedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinChatRequest) Reset() {
-	*x = JoinChatRequest{}
-	mi := &file_chat_proto_msgTypes[3]
+func (x *AgreedPickup) Reset() {
+	*x = AgreedPickup{}
+	mi := &file_chat_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinChatRequest) String() string {
+func (x *AgreedPickup) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinChatRequest) ProtoMessage() {}
+func (*AgreedPickup) ProtoMessage() {}
 
-func (x *JoinChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[3]
+func (x *AgreedPickup) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,48 +1342,89 @@ func (x *JoinChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinChatRequest.ProtoReflect.Descriptor instead.
-func (*JoinChatRequest) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use AgreedPickup.ProtoReflect.Descriptor instead.
+func (*AgreedPickup) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *JoinChatRequest) GetLeftoverId() string {
+func (x *AgreedPickup) GetLeftoverId() string {
 	if x != nil {
 		return x.LeftoverId
 	}
 	return ""
 }
 
-func (x *JoinChatRequest) GetUserId() string {
+func (x *AgreedPickup) GetMessageId() string {
 	if x != nil {
-		return x.UserId
+		return x.MessageId
 	}
 	return ""
 }
 
-type QueueResponse struct {
+func (x *AgreedPickup) GetProposerId() string {
+	if x != nil {
+		return x.ProposerId
+	}
+	return ""
+}
+
+func (x *AgreedPickup) GetAcceptedBy() string {
+	if x != nil {
+		return x.AcceptedBy
+	}
+	return ""
+}
+
+func (x *AgreedPickup) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *AgreedPickup) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *AgreedPickup) GetAcceptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return nil
+}
+
+// ExportTranscriptRequest asks for the stored messages of a room, oldest first.
+// from and to bound the creation time of the messages, unset means unbounded.
+// The owner and admins get every message, guests their own messages and the owner's replies to them.
+type ExportTranscriptRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	QueuedCount   int32                  `protobuf:"varint,1,opt,name=queued_count,json=queuedCount,proto3" json:"queued_count,omitempty"`
-	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	LeftoverId    string                 `protobuf:"bytes,1,opt,name=leftover_id,json=leftoverId,proto3" json:"leftover_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format        TranscriptFormat       `protobuf:"varint,3,opt,name=format,proto3,enum=TranscriptFormat" json:"format,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueueResponse) Reset() {
-	*x = QueueResponse{}
-	mi := &file_chat_proto_msgTypes[4]
+func (x *ExportTranscriptRequest) Reset() {
+	*x = ExportTranscriptRequest{}
+	mi := &file_chat_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueueResponse) String() string {
+func (x *ExportTranscriptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueueResponse) ProtoMessage() {}
+func (*ExportTranscriptRequest) ProtoMessage() {}
 
-func (x *QueueResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_proto_msgTypes[4]
+func (x *ExportTranscriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -320,23 +1435,89 @@ func (x *QueueResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueueResponse.ProtoReflect.Descriptor instead.
-func (*QueueResponse) Descriptor() ([]byte, []int) {
-	return file_chat_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ExportTranscriptRequest.ProtoReflect.Descriptor instead.
+func (*ExportTranscriptRequest) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *QueueResponse) GetQueuedCount() int32 {
+func (x *ExportTranscriptRequest) GetLeftoverId() string {
 	if x != nil {
-		return x.QueuedCount
+		return x.LeftoverId
 	}
-	return 0
+	return ""
 }
 
-func (x *QueueResponse) GetPosition() int32 {
+func (x *ExportTranscriptRequest) GetUserId() string {
 	if x != nil {
-		return x.Position
+		return x.UserId
 	}
-	return 0
+	return ""
+}
+
+func (x *ExportTranscriptRequest) GetFormat() TranscriptFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TranscriptFormat_TRANSCRIPT_FORMAT_JSON_LINES
+}
+
+func (x *ExportTranscriptRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportTranscriptRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// TranscriptLine is one message of the transcript, a JSON object or a line of text.
+type TranscriptLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          string                 `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscriptLine) Reset() {
+	*x = TranscriptLine{}
+	mi := &file_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscriptLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptLine) ProtoMessage() {}
+
+func (x *TranscriptLine) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptLine.ProtoReflect.Descriptor instead.
+func (*TranscriptLine) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TranscriptLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
 }
 
 var File_chat_proto protoreflect.FileDescriptor
@@ -344,23 +1525,59 @@ var File_chat_proto protoreflect.FileDescriptor
 const file_chat_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"chat.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdb\x01\n" +
+	"chat.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0eleftover.proto\"A\n" +
+	"\vLocationPin\x12\x1c\n" +
+	"\x05point\x18\x01 \x01(\v2\x06.PointR\x05point\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"\x97\x01\n" +
+	"\x0ePickupProposal\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12%\n" +
+	"\x06status\x18\x03 \x01(\x0e2\r.PickupStatusR\x06status\"\"\n" +
+	"\fSystemNotice\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\xdc\x03\n" +
 	"\vChatMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vleftover_id\x18\x02 \x01(\tR\n" +
 	"leftoverId\x12\x17\n" +
 	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x18\n" +
 	"\amessage\x18\x04 \x01(\tR\amessage\x12\x14\n" +
-	"\x05image\x18\x05 \x01(\tR\x05image\x12\x17\n" +
-	"\ais_seen\x18\x06 \x01(\bR\x06isSeen\x129\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x129\n" +
 	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"~\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06edited\x18\a \x01(\bR\x06edited\x12\x18\n" +
+	"\adeleted\x18\b \x01(\bR\adeleted\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12 \n" +
+	"\x05event\x18\n" +
+	" \x01(\x0e2\n" +
+	".ChatEventR\x05event\x12*\n" +
+	"\blocation\x18\v \x01(\v2\f.LocationPinH\x00R\blocation\x12)\n" +
+	"\x06pickup\x18\f \x01(\v2\x0f.PickupProposalH\x00R\x06pickup\x12'\n" +
+	"\x06notice\x18\r \x01(\v2\r.SystemNoticeH\x00R\x06noticeB\t\n" +
+	"\apayload\"\xe0\x01\n" +
 	"\x12ChatMessageRequest\x12\x1f\n" +
 	"\vleftover_id\x18\x01 \x01(\tR\n" +
 	"leftoverId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12\x14\n" +
-	"\x05image\x18\x04 \x01(\tR\x05image\"J\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12*\n" +
+	"\blocation\x18\x05 \x01(\v2\f.LocationPinH\x00R\blocation\x12)\n" +
+	"\x06pickup\x18\x06 \x01(\v2\x0f.PickupProposalH\x00R\x06pickupB\t\n" +
+	"\apayload\"\x9d\x01\n" +
+	"\x12EditMessageRequest\x12\x1f\n" +
+	"\vleftover_id\x18\x01 \x01(\tR\n" +
+	"leftoverId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x14\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\"o\n" +
+	"\x14DeleteMessageRequest\x12\x1f\n" +
+	"\vleftover_id\x18\x01 \x01(\tR\n" +
+	"leftoverId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"J\n" +
 	"\x0eEndChatRequest\x12\x1f\n" +
 	"\vleftover_id\x18\x01 \x01(\tR\n" +
 	"leftoverId\x12\x17\n" +
@@ -368,16 +1585,89 @@ const file_chat_proto_rawDesc = "" +
 	"\x0fJoinChatRequest\x12\x1f\n" +
 	"\vleftover_id\x18\x01 \x01(\tR\n" +
 	"leftoverId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"N\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"m\n" +
 	"\rQueueResponse\x12!\n" +
 	"\fqueued_count\x18\x01 \x01(\x05R\vqueuedCount\x12\x1a\n" +
-	"\bposition\x18\x02 \x01(\x05R\bposition2\xb2\x02\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x1d\n" +
+	"\n" +
+	"going_away\x18\x03 \x01(\bR\tgoingAway\"S\n" +
+	"\x10BlockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"-\n" +
+	"\x12ListBlockedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"a\n" +
+	"\vBlockedUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"9\n" +
+	"\x13ListBlockedResponse\x12\"\n" +
+	"\x05items\x18\x01 \x03(\v2\f.BlockedUserR\x05items\"e\n" +
+	"\x0eBanUserRequest\x12\x1f\n" +
+	"\vleftover_id\x18\x01 \x01(\tR\n" +
+	"leftoverId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\x88\x01\n" +
+	"\x15PickupResponseRequest\x12\x1f\n" +
+	"\vleftover_id\x18\x01 \x01(\tR\n" +
+	"leftoverId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06accept\x18\x04 \x01(\bR\x06accept\"O\n" +
+	"\x13AgreedPickupRequest\x12\x1f\n" +
+	"\vleftover_id\x18\x01 \x01(\tR\n" +
+	"leftoverId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xad\x02\n" +
+	"\fAgreedPickup\x12\x1f\n" +
+	"\vleftover_id\x18\x01 \x01(\tR\n" +
+	"leftoverId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x1f\n" +
+	"\vproposer_id\x18\x03 \x01(\tR\n" +
+	"proposerId\x12\x1f\n" +
+	"\vaccepted_by\x18\x04 \x01(\tR\n" +
+	"acceptedBy\x120\n" +
+	"\x05start\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12;\n" +
+	"\vaccepted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acceptedAt\"\xda\x01\n" +
+	"\x17ExportTranscriptRequest\x12\x1f\n" +
+	"\vleftover_id\x18\x01 \x01(\tR\n" +
+	"leftoverId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12)\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x11.TranscriptFormatR\x06format\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"$\n" +
+	"\x0eTranscriptLine\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\tR\x04line*\x9f\x01\n" +
+	"\tChatEvent\x12\x16\n" +
+	"\x12CHAT_EVENT_MESSAGE\x10\x00\x12\x15\n" +
+	"\x11CHAT_EVENT_EDITED\x10\x01\x12\x16\n" +
+	"\x12CHAT_EVENT_DELETED\x10\x02\x12\x16\n" +
+	"\x12CHAT_EVENT_UPDATED\x10\x03\x12\x18\n" +
+	"\x14CHAT_EVENT_HEARTBEAT\x10\x04\x12\x19\n" +
+	"\x15CHAT_EVENT_GOING_AWAY\x10\x05*a\n" +
+	"\fPickupStatus\x12\x19\n" +
+	"\x15PICKUP_STATUS_PENDING\x10\x00\x12\x1a\n" +
+	"\x16PICKUP_STATUS_ACCEPTED\x10\x01\x12\x1a\n" +
+	"\x16PICKUP_STATUS_DECLINED\x10\x02*P\n" +
+	"\x10TranscriptFormat\x12 \n" +
+	"\x1cTRANSCRIPT_FORMAT_JSON_LINES\x10\x00\x12\x1a\n" +
+	"\x16TRANSCRIPT_FORMAT_TEXT\x10\x012\x9a\x06\n" +
 	"\vChatService\x12.\n" +
 	"\bJoinChat\x12\x10.JoinChatRequest\x1a\f.ChatMessage\"\x000\x01\x126\n" +
 	"\x0eWatchChatQueue\x12\x10.JoinChatRequest\x1a\x0e.QueueResponse\"\x000\x01\x12<\n" +
-	"\vSendMessage\x12\x13.ChatMessageRequest\x1a\x16.google.protobuf.Empty\"\x00\x12@\n" +
-	"\x12MarkMessagesAsSeen\x12\x10.JoinChatRequest\x1a\x16.google.protobuf.Empty\"\x00\x12;\n" +
-	"\x0eEndChatSession\x12\x0f.EndChatRequest\x1a\x16.google.protobuf.Empty\"\x00B\bZ\x06./chatb\x06proto3"
+	"\vSendMessage\x12\x13.ChatMessageRequest\x1a\x16.google.protobuf.Empty\"\x00\x12<\n" +
+	"\vEditMessage\x12\x13.EditMessageRequest\x1a\x16.google.protobuf.Empty\"\x00\x12@\n" +
+	"\rDeleteMessage\x12\x15.DeleteMessageRequest\x1a\x16.google.protobuf.Empty\"\x00\x12;\n" +
+	"\x0eEndChatSession\x12\x0f.EndChatRequest\x1a\x16.google.protobuf.Empty\"\x00\x128\n" +
+	"\tBlockUser\x12\x11.BlockUserRequest\x1a\x16.google.protobuf.Empty\"\x00\x12:\n" +
+	"\vUnblockUser\x12\x11.BlockUserRequest\x1a\x16.google.protobuf.Empty\"\x00\x12:\n" +
+	"\vListBlocked\x12\x13.ListBlockedRequest\x1a\x14.ListBlockedResponse\"\x00\x124\n" +
+	"\aBanUser\x12\x0f.BanUserRequest\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
+	"\x0fRespondToPickup\x12\x16.PickupResponseRequest\x1a\x16.google.protobuf.Empty\"\x00\x128\n" +
+	"\x0fGetAgreedPickup\x12\x14.AgreedPickupRequest\x1a\r.AgreedPickup\"\x00\x12A\n" +
+	"\x10ExportTranscript\x12\x18.ExportTranscriptRequest\x1a\x0f.TranscriptLine\"\x000\x01B\x13Z\x11lovco/server/chatb\x06proto3"
 
 var (
 	file_chat_proto_rawDescOnce sync.Once
@@ -391,33 +1681,88 @@ func file_chat_proto_rawDescGZIP() []byte {
 	return file_chat_proto_rawDescData
 }
 
-var file_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_chat_proto_goTypes = []any{
-	(*ChatMessage)(nil),           // 0: ChatMessage
-	(*ChatMessageRequest)(nil),    // 1: ChatMessageRequest
-	(*EndChatRequest)(nil),        // 2: EndChatRequest
-	(*JoinChatRequest)(nil),       // 3: JoinChatRequest
-	(*QueueResponse)(nil),         // 4: QueueResponse
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 6: google.protobuf.Empty
+	(ChatEvent)(0),                  // 0: ChatEvent
+	(PickupStatus)(0),               // 1: PickupStatus
+	(TranscriptFormat)(0),           // 2: TranscriptFormat
+	(*LocationPin)(nil),             // 3: LocationPin
+	(*PickupProposal)(nil),          // 4: PickupProposal
+	(*SystemNotice)(nil),            // 5: SystemNotice
+	(*ChatMessage)(nil),             // 6: ChatMessage
+	(*ChatMessageRequest)(nil),      // 7: ChatMessageRequest
+	(*EditMessageRequest)(nil),      // 8: EditMessageRequest
+	(*DeleteMessageRequest)(nil),    // 9: DeleteMessageRequest
+	(*EndChatRequest)(nil),          // 10: EndChatRequest
+	(*JoinChatRequest)(nil),         // 11: JoinChatRequest
+	(*QueueResponse)(nil),           // 12: QueueResponse
+	(*BlockUserRequest)(nil),        // 13: BlockUserRequest
+	(*ListBlockedRequest)(nil),      // 14: ListBlockedRequest
+	(*BlockedUser)(nil),             // 15: BlockedUser
+	(*ListBlockedResponse)(nil),     // 16: ListBlockedResponse
+	(*BanUserRequest)(nil),          // 17: BanUserRequest
+	(*PickupResponseRequest)(nil),   // 18: PickupResponseRequest
+	(*AgreedPickupRequest)(nil),     // 19: AgreedPickupRequest
+	(*AgreedPickup)(nil),            // 20: AgreedPickup
+	(*ExportTranscriptRequest)(nil), // 21: ExportTranscriptRequest
+	(*TranscriptLine)(nil),          // 22: TranscriptLine
+	(*leftover.Point)(nil),          // 23: Point
+	(*timestamppb.Timestamp)(nil),   // 24: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),           // 25: google.protobuf.Empty
 }
 var file_chat_proto_depIdxs = []int32{
-	5, // 0: ChatMessage.created_at:type_name -> google.protobuf.Timestamp
-	3, // 1: ChatService.JoinChat:input_type -> JoinChatRequest
-	3, // 2: ChatService.WatchChatQueue:input_type -> JoinChatRequest
-	1, // 3: ChatService.SendMessage:input_type -> ChatMessageRequest
-	3, // 4: ChatService.MarkMessagesAsSeen:input_type -> JoinChatRequest
-	2, // 5: ChatService.EndChatSession:input_type -> EndChatRequest
-	0, // 6: ChatService.JoinChat:output_type -> ChatMessage
-	4, // 7: ChatService.WatchChatQueue:output_type -> QueueResponse
-	6, // 8: ChatService.SendMessage:output_type -> google.protobuf.Empty
-	6, // 9: ChatService.MarkMessagesAsSeen:output_type -> google.protobuf.Empty
-	6, // 10: ChatService.EndChatSession:output_type -> google.protobuf.Empty
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	23, // 0: LocationPin.point:type_name -> Point
+	24, // 1: PickupProposal.start:type_name -> google.protobuf.Timestamp
+	24, // 2: PickupProposal.end:type_name -> google.protobuf.Timestamp
+	1,  // 3: PickupProposal.status:type_name -> PickupStatus
+	24, // 4: ChatMessage.created_at:type_name -> google.protobuf.Timestamp
+	24, // 5: ChatMessage.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: ChatMessage.event:type_name -> ChatEvent
+	3,  // 7: ChatMessage.location:type_name -> LocationPin
+	4,  // 8: ChatMessage.pickup:type_name -> PickupProposal
+	5,  // 9: ChatMessage.notice:type_name -> SystemNotice
+	3,  // 10: ChatMessageRequest.location:type_name -> LocationPin
+	4,  // 11: ChatMessageRequest.pickup:type_name -> PickupProposal
+	24, // 12: BlockedUser.created_at:type_name -> google.protobuf.Timestamp
+	15, // 13: ListBlockedResponse.items:type_name -> BlockedUser
+	24, // 14: AgreedPickup.start:type_name -> google.protobuf.Timestamp
+	24, // 15: AgreedPickup.end:type_name -> google.protobuf.Timestamp
+	24, // 16: AgreedPickup.accepted_at:type_name -> google.protobuf.Timestamp
+	2,  // 17: ExportTranscriptRequest.format:type_name -> TranscriptFormat
+	24, // 18: ExportTranscriptRequest.from:type_name -> google.protobuf.Timestamp
+	24, // 19: ExportTranscriptRequest.to:type_name -> google.protobuf.Timestamp
+	11, // 20: ChatService.JoinChat:input_type -> JoinChatRequest
+	11, // 21: ChatService.WatchChatQueue:input_type -> JoinChatRequest
+	7,  // 22: ChatService.SendMessage:input_type -> ChatMessageRequest
+	8,  // 23: ChatService.EditMessage:input_type -> EditMessageRequest
+	9,  // 24: ChatService.DeleteMessage:input_type -> DeleteMessageRequest
+	10, // 25: ChatService.EndChatSession:input_type -> EndChatRequest
+	13, // 26: ChatService.BlockUser:input_type -> BlockUserRequest
+	13, // 27: ChatService.UnblockUser:input_type -> BlockUserRequest
+	14, // 28: ChatService.ListBlocked:input_type -> ListBlockedRequest
+	17, // 29: ChatService.BanUser:input_type -> BanUserRequest
+	18, // 30: ChatService.RespondToPickup:input_type -> PickupResponseRequest
+	19, // 31: ChatService.GetAgreedPickup:input_type -> AgreedPickupRequest
+	21, // 32: ChatService.ExportTranscript:input_type -> ExportTranscriptRequest
+	6,  // 33: ChatService.JoinChat:output_type -> ChatMessage
+	12, // 34: ChatService.WatchChatQueue:output_type -> QueueResponse
+	25, // 35: ChatService.SendMessage:output_type -> google.protobuf.Empty
+	25, // 36: ChatService.EditMessage:output_type -> google.protobuf.Empty
+	25, // 37: ChatService.DeleteMessage:output_type -> google.protobuf.Empty
+	25, // 38: ChatService.EndChatSession:output_type -> google.protobuf.Empty
+	25, // 39: ChatService.BlockUser:output_type -> google.protobuf.Empty
+	25, // 40: ChatService.UnblockUser:output_type -> google.protobuf.Empty
+	16, // 41: ChatService.ListBlocked:output_type -> ListBlockedResponse
+	25, // 42: ChatService.BanUser:output_type -> google.protobuf.Empty
+	25, // 43: ChatService.RespondToPickup:output_type -> google.protobuf.Empty
+	20, // 44: ChatService.GetAgreedPickup:output_type -> AgreedPickup
+	22, // 45: ChatService.ExportTranscript:output_type -> TranscriptLine
+	33, // [33:46] is the sub-list for method output_type
+	20, // [20:33] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_chat_proto_init() }
@@ -425,18 +1770,28 @@ func file_chat_proto_init() {
 	if File_chat_proto != nil {
 		return
 	}
+	file_chat_proto_msgTypes[3].OneofWrappers = []any{
+		(*ChatMessage_Location)(nil),
+		(*ChatMessage_Pickup)(nil),
+		(*ChatMessage_Notice)(nil),
+	}
+	file_chat_proto_msgTypes[4].OneofWrappers = []any{
+		(*ChatMessageRequest_Location)(nil),
+		(*ChatMessageRequest_Pickup)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_proto_rawDesc), len(file_chat_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      3,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_chat_proto_goTypes,
 		DependencyIndexes: file_chat_proto_depIdxs,
+		EnumInfos:         file_chat_proto_enumTypes,
 		MessageInfos:      file_chat_proto_msgTypes,
 	}.Build()
 	File_chat_proto = out.File
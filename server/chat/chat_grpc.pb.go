@@ -20,11 +20,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChatService_JoinChat_FullMethodName           = "/ChatService/JoinChat"
-	ChatService_WatchChatQueue_FullMethodName     = "/ChatService/WatchChatQueue"
-	ChatService_SendMessage_FullMethodName        = "/ChatService/SendMessage"
-	ChatService_MarkMessagesAsSeen_FullMethodName = "/ChatService/MarkMessagesAsSeen"
-	ChatService_EndChatSession_FullMethodName     = "/ChatService/EndChatSession"
+	ChatService_JoinChat_FullMethodName         = "/ChatService/JoinChat"
+	ChatService_WatchChatQueue_FullMethodName   = "/ChatService/WatchChatQueue"
+	ChatService_SendMessage_FullMethodName      = "/ChatService/SendMessage"
+	ChatService_EditMessage_FullMethodName      = "/ChatService/EditMessage"
+	ChatService_DeleteMessage_FullMethodName    = "/ChatService/DeleteMessage"
+	ChatService_EndChatSession_FullMethodName   = "/ChatService/EndChatSession"
+	ChatService_BlockUser_FullMethodName        = "/ChatService/BlockUser"
+	ChatService_UnblockUser_FullMethodName      = "/ChatService/UnblockUser"
+	ChatService_ListBlocked_FullMethodName      = "/ChatService/ListBlocked"
+	ChatService_BanUser_FullMethodName          = "/ChatService/BanUser"
+	ChatService_RespondToPickup_FullMethodName  = "/ChatService/RespondToPickup"
+	ChatService_GetAgreedPickup_FullMethodName  = "/ChatService/GetAgreedPickup"
+	ChatService_ExportTranscript_FullMethodName = "/ChatService/ExportTranscript"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -34,8 +42,16 @@ type ChatServiceClient interface {
 	JoinChat(ctx context.Context, in *JoinChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatMessage], error)
 	WatchChatQueue(ctx context.Context, in *JoinChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueueResponse], error)
 	SendMessage(ctx context.Context, in *ChatMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	MarkMessagesAsSeen(ctx context.Context, in *JoinChatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	EndChatSession(ctx context.Context, in *EndChatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnblockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error)
+	BanUser(ctx context.Context, in *BanUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RespondToPickup(ctx context.Context, in *PickupResponseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAgreedPickup(ctx context.Context, in *AgreedPickupRequest, opts ...grpc.CallOption) (*AgreedPickup, error)
+	ExportTranscript(ctx context.Context, in *ExportTranscriptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranscriptLine], error)
 }
 
 type chatServiceClient struct {
@@ -94,10 +110,20 @@ func (c *chatServiceClient) SendMessage(ctx context.Context, in *ChatMessageRequ
 	return out, nil
 }
 
-func (c *chatServiceClient) MarkMessagesAsSeen(ctx context.Context, in *JoinChatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *chatServiceClient) EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, ChatService_MarkMessagesAsSeen_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, ChatService_EditMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteMessage_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +140,85 @@ func (c *chatServiceClient) EndChatSession(ctx context.Context, in *EndChatReque
 	return out, nil
 }
 
+func (c *chatServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UnblockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlockedResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) BanUser(ctx context.Context, in *BanUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_BanUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RespondToPickup(ctx context.Context, in *PickupResponseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_RespondToPickup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetAgreedPickup(ctx context.Context, in *AgreedPickupRequest, opts ...grpc.CallOption) (*AgreedPickup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AgreedPickup)
+	err := c.cc.Invoke(ctx, ChatService_GetAgreedPickup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ExportTranscript(ctx context.Context, in *ExportTranscriptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranscriptLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[2], ChatService_ExportTranscript_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportTranscriptRequest, TranscriptLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_ExportTranscriptClient = grpc.ServerStreamingClient[TranscriptLine]
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
@@ -121,8 +226,16 @@ type ChatServiceServer interface {
 	JoinChat(*JoinChatRequest, grpc.ServerStreamingServer[ChatMessage]) error
 	WatchChatQueue(*JoinChatRequest, grpc.ServerStreamingServer[QueueResponse]) error
 	SendMessage(context.Context, *ChatMessageRequest) (*emptypb.Empty, error)
-	MarkMessagesAsSeen(context.Context, *JoinChatRequest) (*emptypb.Empty, error)
+	EditMessage(context.Context, *EditMessageRequest) (*emptypb.Empty, error)
+	DeleteMessage(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error)
 	EndChatSession(context.Context, *EndChatRequest) (*emptypb.Empty, error)
+	BlockUser(context.Context, *BlockUserRequest) (*emptypb.Empty, error)
+	UnblockUser(context.Context, *BlockUserRequest) (*emptypb.Empty, error)
+	ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error)
+	BanUser(context.Context, *BanUserRequest) (*emptypb.Empty, error)
+	RespondToPickup(context.Context, *PickupResponseRequest) (*emptypb.Empty, error)
+	GetAgreedPickup(context.Context, *AgreedPickupRequest) (*AgreedPickup, error)
+	ExportTranscript(*ExportTranscriptRequest, grpc.ServerStreamingServer[TranscriptLine]) error
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -142,12 +255,36 @@ func (UnimplementedChatServiceServer) WatchChatQueue(*JoinChatRequest, grpc.Serv
 func (UnimplementedChatServiceServer) SendMessage(context.Context, *ChatMessageRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
 }
-func (UnimplementedChatServiceServer) MarkMessagesAsSeen(context.Context, *JoinChatRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MarkMessagesAsSeen not implemented")
+func (UnimplementedChatServiceServer) EditMessage(context.Context, *EditMessageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditMessage not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteMessage(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMessage not implemented")
 }
 func (UnimplementedChatServiceServer) EndChatSession(context.Context, *EndChatRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EndChatSession not implemented")
 }
+func (UnimplementedChatServiceServer) BlockUser(context.Context, *BlockUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedChatServiceServer) UnblockUser(context.Context, *BlockUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedChatServiceServer) ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocked not implemented")
+}
+func (UnimplementedChatServiceServer) BanUser(context.Context, *BanUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanUser not implemented")
+}
+func (UnimplementedChatServiceServer) RespondToPickup(context.Context, *PickupResponseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToPickup not implemented")
+}
+func (UnimplementedChatServiceServer) GetAgreedPickup(context.Context, *AgreedPickupRequest) (*AgreedPickup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgreedPickup not implemented")
+}
+func (UnimplementedChatServiceServer) ExportTranscript(*ExportTranscriptRequest, grpc.ServerStreamingServer[TranscriptLine]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTranscript not implemented")
+}
 func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
 func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
@@ -209,20 +346,38 @@ func _ChatService_SendMessage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_MarkMessagesAsSeen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinChatRequest)
+func _ChatService_EditMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).EditMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_EditMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).EditMessage(ctx, req.(*EditMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).MarkMessagesAsSeen(ctx, in)
+		return srv.(ChatServiceServer).DeleteMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_MarkMessagesAsSeen_FullMethodName,
+		FullMethod: ChatService_DeleteMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).MarkMessagesAsSeen(ctx, req.(*JoinChatRequest))
+		return srv.(ChatServiceServer).DeleteMessage(ctx, req.(*DeleteMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -245,6 +400,125 @@ func _ChatService_EndChatSession_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UnblockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListBlocked(ctx, req.(*ListBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_BanUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).BanUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_BanUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).BanUser(ctx, req.(*BanUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RespondToPickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickupResponseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RespondToPickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RespondToPickup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RespondToPickup(ctx, req.(*PickupResponseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetAgreedPickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgreedPickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetAgreedPickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetAgreedPickup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetAgreedPickup(ctx, req.(*AgreedPickupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ExportTranscript_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTranscriptRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChatServiceServer).ExportTranscript(m, &grpc.GenericServerStream[ExportTranscriptRequest, TranscriptLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_ExportTranscriptServer = grpc.ServerStreamingServer[TranscriptLine]
+
 // ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -257,13 +531,41 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ChatService_SendMessage_Handler,
 		},
 		{
-			MethodName: "MarkMessagesAsSeen",
-			Handler:    _ChatService_MarkMessagesAsSeen_Handler,
+			MethodName: "EditMessage",
+			Handler:    _ChatService_EditMessage_Handler,
+		},
+		{
+			MethodName: "DeleteMessage",
+			Handler:    _ChatService_DeleteMessage_Handler,
 		},
 		{
 			MethodName: "EndChatSession",
 			Handler:    _ChatService_EndChatSession_Handler,
 		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _ChatService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _ChatService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "ListBlocked",
+			Handler:    _ChatService_ListBlocked_Handler,
+		},
+		{
+			MethodName: "BanUser",
+			Handler:    _ChatService_BanUser_Handler,
+		},
+		{
+			MethodName: "RespondToPickup",
+			Handler:    _ChatService_RespondToPickup_Handler,
+		},
+		{
+			MethodName: "GetAgreedPickup",
+			Handler:    _ChatService_GetAgreedPickup_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -276,6 +578,11 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ChatService_WatchChatQueue_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportTranscript",
+			Handler:       _ChatService_ExportTranscript_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "chat.proto",
 }
@@ -0,0 +1,233 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"lovco/server/logging"
+	"lovco/server/webhook"
+	"time"
+
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// eventsTopic is the broker topic shared by all rooms.
+const eventsTopic = "lovco_chat_events"
+
+// storeTimeout bounds the store work done while applying a single event.
+const storeTimeout = 5 * time.Second
+
+type eventKind string
+
+const (
+	eventJoin    eventKind = "join"
+	eventLeave   eventKind = "leave"
+	eventMessage eventKind = "message"
+	eventKick    eventKind = "kick"
+)
+
+// roomEvent is a change to a room, published to the broker and applied by every replica.
+// Messages are stored before they are broadcast, an event only names the message and
+// the replicas with streams in the room read it from the store. That keeps events far
+// below the NOTIFY payload limit of Postgres whatever the size of the message.
+//
+// Events published while the broker subscription of a replica reconnects are lost for that replica.
+// Its streams miss the messages of that time, clients get them again with the history when they rejoin.
+// Joins and leaves missed meanwhile leave the rooms of the replica out of date until they are loaded again,
+// the readiness check of the broker fails while it reconnects so no new calls are routed to it.
+type roomEvent struct {
+	Kind         eventKind `json:"kind"`
+	Origin       string    `json:"origin"` // replica that published the event
+	At           time.Time `json:"at"`
+	RoomID       string    `json:"room_id"`
+	UserID       string    `json:"user_id,omitempty"`
+	IsOwner      bool      `json:"is_owner,omitempty"`
+	MessageID    string    `json:"message_id,omitempty"`
+	MessageEvent ChatEvent `json:"message_event,omitempty"` // what happened to the message
+}
+
+func encodeEvent(ev roomEvent) ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+func decodeEvent(payload []byte) (roomEvent, error) {
+	var ev roomEvent
+	err := json.Unmarshal(payload, &ev)
+	return ev, err
+}
+
+func (h *hub) publish(ctx context.Context, ev roomEvent) error {
+	ev.Origin = h.node
+	ev.At = time.Now().UTC()
+	payload, err := encodeEvent(ev)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode room event: %v", err)
+	}
+	if err := h.broker.Publish(ctx, eventsTopic, payload); err != nil {
+		return status.Errorf(codes.Unavailable, "failed to publish room event: %v", err)
+	}
+	return nil
+}
+
+// run hands the room events coming from the broker to the rooms until the subscription ends.
+// It never waits for a room, the store or a stream, so one of them being slow does not hold up the others.
+func (h *hub) run(events <-chan []byte) {
+	for payload := range events {
+		ev, err := decodeEvent(payload)
+		if err != nil {
+			h.logger.Error("failed to decode room event", "error", err)
+			continue
+		}
+		h.dispatch(ev)
+	}
+}
+
+// dispatch queues ev on its room and starts the worker of the room when it is not running.
+func (h *hub) dispatch(ev roomEvent) {
+	var room *room
+	created := false
+	switch {
+	case ev.Kind == eventJoin:
+		// rooms are created on every replica by the first join
+		room, created = h.roomEntry(ev.RoomID)
+	case ev.Origin == h.node && (ev.Kind == eventLeave || ev.Kind == eventKick):
+		// the replica that published a leave stores the room, it may not have it loaded yet
+		room, created = h.roomEntry(ev.RoomID)
+	default:
+		room = h.lookupRoom(ev.RoomID)
+	}
+	if room == nil {
+		return
+	}
+
+	room.mu.Lock()
+	if room.evicted {
+		room.mu.Unlock()
+		h.dispatch(ev)
+		return
+	}
+	room.pending = append(room.pending, ev)
+	start := !room.working
+	room.working = true
+	room.mu.Unlock()
+	if start {
+		go h.work(ev.RoomID, room, created)
+	}
+}
+
+// work applies the pending events of a room in order until there are none left.
+// load is set when the room was created for the events and still has to be loaded.
+func (h *hub) work(roomID string, room *room, load bool) {
+	if load {
+		ctx, cancel := context.WithTimeout(logging.NewContext(context.Background(), h.logger), storeTimeout)
+		h.load(ctx, roomID, room)
+		cancel()
+	}
+	// a room created by a call is loaded by that call
+	<-room.ready
+
+	for {
+		room.mu.Lock()
+		if len(room.pending) == 0 {
+			room.working = false
+			room.mu.Unlock()
+			h.evict(roomID, room)
+			return
+		}
+		ev := room.pending[0]
+		room.pending = room.pending[1:]
+		room.mu.Unlock()
+
+		h.apply(room, ev)
+	}
+}
+
+func (h *hub) apply(room *room, ev roomEvent) {
+	ctx, cancel := context.WithTimeout(logging.NewContext(context.Background(), h.logger), storeTimeout)
+	defer cancel()
+
+	switch ev.Kind {
+	case eventJoin:
+		room.mu.Lock()
+		guest := room.guestID
+		room.join(ev.RoomID, ev.UserID, ev.IsOwner, h.opts.MaxQueueLength)
+		snap := room.snapshot(ev.At)
+		room.mu.Unlock()
+		h.save(ctx, ev, snap)
+		h.announce(ctx, ev, guest, snap.GuestID)
+	case eventLeave, eventKick:
+		room.mu.Lock()
+		guest := room.guestID
+		if ev.Kind == eventKick {
+			room.kick(ev.RoomID, ev.UserID, status.Errorf(codes.PermissionDenied, "user is not allowed in the chat room"))
+		} else {
+			room.vacate(ev.RoomID, ev.UserID)
+		}
+		snap := room.snapshot(ev.At)
+		room.mu.Unlock()
+		h.save(ctx, ev, snap)
+		h.announce(ctx, ev, guest, snap.GuestID)
+	case eventMessage:
+		room.mu.Lock()
+		listening := len(room.slots) > 0
+		room.mu.Unlock()
+		// a stream seated after this still gets the message with the history
+		if !listening {
+			return
+		}
+		msg, err := h.store.GetMessage(ctx, ev.RoomID, ev.MessageID)
+		if err != nil {
+			h.logger.Error("failed to load broadcast message", "leftover_id", ev.RoomID, "message_id", ev.MessageID, "error", err)
+			return
+		}
+		msg.Event = ev.MessageEvent
+
+		room.mu.Lock()
+		dropped := room.deliver(msg)
+		room.mu.Unlock()
+		if dropped > 0 {
+			h.sendFailures.Add(uint64(dropped))
+			room.logger.Warn("dropped slow chat streams", "leftover_id", ev.RoomID, "streams", dropped)
+		}
+	default:
+		h.logger.Warn("unknown room event", "kind", ev.Kind, "leftover_id", ev.RoomID)
+	}
+}
+
+// save stores the room state after an event. Only the replica that published the event
+// writes it, the others applied the same change and would write the same state.
+func (h *hub) save(ctx context.Context, ev roomEvent, snap RoomSnapshot) {
+	if ev.Origin != h.node {
+		return
+	}
+	if err := h.store.SaveRoom(ctx, ev.RoomID, snap); err != nil {
+		h.logger.Error("failed to store room", "leftover_id", ev.RoomID, "error", err)
+	}
+}
+
+// sessionEvent is the webhook payload of the chat session lifecycle.
+type sessionEvent struct {
+	LeftoverID string    `json:"leftover_id"`
+	GuestID    string    `json:"guest_id"`
+	At         time.Time `json:"at"`
+}
+
+// announce tells the webhooks when the guest seat of a room changed hands.
+// Like save, only the replica that published the event does it.
+func (h *hub) announce(ctx context.Context, ev roomEvent, before string, after string) {
+	if ev.Origin != h.node || before == after {
+		return
+	}
+	if before != "" {
+		h.emit(ctx, webhook.EventChatSessionEnded, sessionEvent{LeftoverID: ev.RoomID, GuestID: before, At: ev.At})
+	}
+	if after != "" {
+		h.emit(ctx, webhook.EventChatSessionStarted, sessionEvent{LeftoverID: ev.RoomID, GuestID: after, At: ev.At})
+	}
+}
+
+func (h *hub) emit(ctx context.Context, name string, data sessionEvent) {
+	if err := h.webhooks.EmitForLeftover(ctx, name, data.LeftoverID, data); err != nil {
+		logging.FromContext(ctx).Error("failed to emit webhook event", "event", name, "leftover_id", data.LeftoverID, "error", err)
+	}
+}
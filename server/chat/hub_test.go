@@ -0,0 +1,362 @@
+package chat_test
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"lovco/server/chat"
+	"lovco/server/leftover"
+	"lovco/server/pubsub"
+	"lovco/server/storage"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	leftoverID = "5b0c3f0e-8a3e-4a55-9b9f-2f0c2b6f1d10"
+	owner      = "1d3c1a52-7f0b-4a8e-8c67-0f4b7d3e2a01"
+	guest      = "6e2f9b1c-3a4d-4f5e-9a8b-1c2d3e4f5a02"
+	other      = "9f8e7d6c-5b4a-4c3d-8e2f-1a0b9c8d7e03"
+	stranger   = "2c4e6a80-1b3d-4f5a-9c7e-3d5f7a9b1c04"
+)
+
+// stream is the server side of a test client. Send waits for gate when it is set,
+// like a client that stopped reading.
+type stream[T any] struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *T
+	gate chan struct{}
+}
+
+func newStream[T any](ctx context.Context) *stream[T] {
+	return &stream[T]{ctx: ctx, sent: make(chan *T, 1000)}
+}
+
+func (s *stream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *stream[T]) Send(msg *T) error {
+	if s.gate != nil {
+		select {
+		case <-s.gate:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+	select {
+	case s.sent <- msg:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func newStore(t *testing.T) *storage.MemoryStore {
+	t.Helper()
+	store := storage.NewMemoryStore()
+	lo := &leftover.Leftover{Id: leftoverID, OwnerId: owner, Name: "Bread", Type: "food"}
+	if err := store.Leftovers().Add(context.Background(), lo); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// newServer starts a replica on its own broker, like a server restarted on the same database.
+func newServer(t *testing.T, store *storage.MemoryStore, opts chat.Options) *chat.ChatServer {
+	t.Helper()
+	opts.Logger = slog.New(slog.DiscardHandler)
+	s := chat.NewChatServer(store.Chats(), store.Webhooks(), pubsub.NewMemoryBroker(), opts)
+	t.Cleanup(s.Stop)
+	return s
+}
+
+// client is a JoinChat call running in the background.
+type client struct {
+	stream *stream[chat.ChatMessage]
+	cancel context.CancelFunc
+	done   chan error
+}
+
+func join(s *chat.ChatServer, uid string, gate chan struct{}) *client {
+	ctx, cancel := context.WithCancel(context.Background())
+	st := newStream[chat.ChatMessage](ctx)
+	st.gate = gate
+	c := &client{stream: st, cancel: cancel, done: make(chan error, 1)}
+	go func() {
+		c.done <- s.JoinChat(&chat.JoinChatRequest{LeftoverId: leftoverID, UserId: uid}, st)
+	}()
+	return c
+}
+
+// ended returns the error the call of c ended with.
+func (c *client) ended(t *testing.T) error {
+	t.Helper()
+	select {
+	case err := <-c.done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("the chat stream did not end")
+		return nil
+	}
+}
+
+func send(s *chat.ChatServer, uid string, text string) error {
+	_, err := s.SendMessage(context.Background(), &chat.ChatMessageRequest{LeftoverId: leftoverID, UserId: uid, Message: text})
+	return err
+}
+
+func seated(s *chat.ChatServer, uid string) func() bool {
+	return func() bool { return send(s, uid, "hello") == nil }
+}
+
+// eventually fails t when cond does not hold within a few seconds.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting until %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// watch returns the first queue update uid gets.
+func watch(t *testing.T, s *chat.ChatServer, uid string) (*chat.QueueResponse, error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	st := newStream[chat.QueueResponse](ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WatchChatQueue(&chat.JoinChatRequest{LeftoverId: leftoverID, UserId: uid}, st)
+	}()
+
+	select {
+	case update := <-st.sent:
+		return update, nil
+	case err := <-done:
+		return nil, err
+	case <-time.After(5 * time.Second):
+		t.Fatal("no queue update")
+		return nil, nil
+	}
+}
+
+func TestSlowStreamIsDropped(t *testing.T) {
+	s := newServer(t, newStore(t), chat.Options{})
+
+	// the owner stops reading with the first event
+	gate := make(chan struct{})
+	slow := join(s, owner, gate)
+	defer slow.cancel()
+	eventually(t, "the owner is seated", seated(s, owner))
+	fast := join(s, guest, nil)
+	defer fast.cancel()
+	eventually(t, "the guest is seated", seated(s, guest))
+
+	// the guest reads along, only the owner falls behind
+	const n, batch = 300, 20
+	got := 0
+	timeout := time.After(5 * time.Second)
+	for sent := 0; sent < n; {
+		for range batch {
+			if err := send(s, guest, "flood "+strconv.Itoa(sent)); err != nil {
+				t.Fatal(err)
+			}
+			sent++
+		}
+		for got < sent {
+			select {
+			case msg := <-fast.stream.sent:
+				if strings.HasPrefix(msg.Message, "flood ") && msg.Event == chat.ChatEvent_CHAT_EVENT_MESSAGE {
+					got++
+				}
+			case <-timeout:
+				t.Fatalf("the guest got %d of %d messages while the owner was not reading", got, sent)
+			}
+		}
+	}
+
+	close(gate)
+	if err := slow.ended(t); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("slow stream ended with %v, want ResourceExhausted", err)
+	}
+}
+
+func TestHeartbeatOnlyOnIdleStreams(t *testing.T) {
+	const interval = 150 * time.Millisecond
+	s := newServer(t, newStore(t), chat.Options{HeartbeatInterval: interval})
+
+	c := join(s, owner, nil)
+	defer c.cancel()
+	eventually(t, "the owner is seated", seated(s, owner))
+
+	// a message every third of the interval keeps the stream busy
+	for range 15 {
+		if err := send(s, owner, "busy"); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(interval / 3)
+	}
+	for len(c.stream.sent) > 0 {
+		if msg := <-c.stream.sent; msg.Event == chat.ChatEvent_CHAT_EVENT_HEARTBEAT {
+			t.Fatal("a busy stream got a heartbeat")
+		}
+	}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-c.stream.sent:
+			if msg.Event == chat.ChatEvent_CHAT_EVENT_HEARTBEAT {
+				return
+			}
+		case <-timeout:
+			t.Fatal("an idle stream got no heartbeat")
+		}
+	}
+}
+
+func TestDisconnectedSeatIsHeld(t *testing.T) {
+	const grace = 300 * time.Millisecond
+	s := newServer(t, newStore(t), chat.Options{ReconnectGrace: grace})
+
+	first := join(s, guest, nil)
+	eventually(t, "the guest is seated", seated(s, guest))
+	queued := join(s, other, nil)
+	defer queued.cancel()
+
+	first.cancel()
+	first.ended(t)
+	if seated(s, other)() {
+		t.Fatal("the queued user got the seat of a guest within the grace period")
+	}
+
+	back := join(s, guest, nil)
+	select {
+	case <-back.stream.sent:
+		// the history replay means the guest got the seat back
+	case <-time.After(5 * time.Second):
+		t.Fatal("the guest did not get the held seat back")
+	}
+
+	time.Sleep(2 * grace)
+	if seated(s, other)() {
+		t.Fatal("the seat was released although the guest came back")
+	}
+
+	back.cancel()
+	back.ended(t)
+	start := time.Now()
+	eventually(t, "the queued user gets the seat", seated(s, other))
+	if waited := time.Since(start); waited < grace/2 {
+		t.Errorf("the seat was released after %s, before the grace period", waited)
+	}
+}
+
+func TestSecondStreamKeepsQueuePosition(t *testing.T) {
+	s := newServer(t, newStore(t), chat.Options{})
+
+	seatedGuest := join(s, guest, nil)
+	defer seatedGuest.cancel()
+	eventually(t, "the guest is seated", seated(s, guest))
+	first := join(s, other, nil)
+	defer first.cancel()
+	eventually(t, "the user is queued", func() bool {
+		update, err := watch(t, s, other)
+		return err == nil && update.Position == 1
+	})
+
+	second := join(s, other, nil)
+	defer second.cancel()
+	if err := first.ended(t); status.Code(err) != codes.Aborted {
+		t.Errorf("replaced stream ended with %v, want Aborted", err)
+	}
+	first.cancel()
+
+	update, err := watch(t, s, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.Position != 1 {
+		t.Errorf("position %d after the first stream ended, want 1", update.Position)
+	}
+
+	seatedGuest.cancel()
+	seatedGuest.ended(t)
+	select {
+	case <-second.stream.sent:
+		// the history replay means the second stream got the seat
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second stream did not get the seat")
+	}
+}
+
+func TestRoomIsRestored(t *testing.T) {
+	store := newStore(t)
+	before := newServer(t, store, chat.Options{RestoreWindow: time.Minute})
+
+	clients := []*client{join(before, owner, nil)}
+	eventually(t, "the owner is seated", seated(before, owner))
+	clients = append(clients, join(before, guest, nil))
+	eventually(t, "the guest is seated", seated(before, guest))
+	clients = append(clients, join(before, other, nil))
+	eventually(t, "the queue is stored", func() bool {
+		snap, err := store.Chats().LoadRoom(context.Background(), leftoverID)
+		return err == nil && snap != nil && len(snap.Queue) == 1
+	})
+
+	// draining keeps the seats and the queue for the next start
+	before.Drain()
+	for _, c := range clients {
+		if err := c.ended(t); status.Code(err) != codes.Unavailable {
+			t.Errorf("stream ended with %v, want Unavailable", err)
+		}
+	}
+	before.Stop()
+
+	after := newServer(t, store, chat.Options{RestoreWindow: time.Minute})
+	tests := []struct {
+		uid      string
+		position int32
+	}{
+		{guest, 0},
+		{other, 1},
+	}
+	for _, tt := range tests {
+		update, err := watch(t, after, tt.uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if update.Position != tt.position || update.QueuedCount != 1 {
+			t.Errorf("%s: position %d of %d, want %d of 1", tt.uid, update.Position, update.QueuedCount, tt.position)
+		}
+	}
+
+	// too late, everybody starts over
+	late := newServer(t, store, chat.Options{RestoreWindow: time.Nanosecond})
+	update, err := watch(t, late, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.Position != -1 || update.QueuedCount != 0 {
+		t.Errorf("position %d of %d after the restore window, want -1 of 0", update.Position, update.QueuedCount)
+	}
+}
+
+func TestWatchUnknownRoom(t *testing.T) {
+	s := newServer(t, newStore(t), chat.Options{})
+
+	if _, err := watch(t, s, other); status.Code(err) != codes.NotFound {
+		t.Errorf("watching a room nobody joined: %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,227 @@
+package chat
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// sweepInterval is how often idle limiter state is dropped.
+const sweepInterval = time.Minute
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// bucket is a token bucket, it holds at most burst tokens and gains rate tokens per second.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter keeps a token bucket per key. A rate of 0 disables it.
+type limiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	return &limiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// allow takes a token for key, or returns how long to wait for the next one.
+func (l *limiter) allow(key string, now time.Time) (bool, time.Duration) {
+	if l.rate <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops the buckets that refilled completely, they behave like new ones.
+// l.mu must be held by the caller.
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// sent is a recent message of a user as seen by the spam detector.
+type sent struct {
+	at    time.Time
+	text  string
+	links int
+}
+
+// spamDetector mutes users who repeat the same message or post too many links within a window.
+// A limit of 0 disables that heuristic.
+type spamDetector struct {
+	mu        sync.Mutex
+	window    time.Duration
+	repeats   int
+	links     int
+	mute      time.Duration
+	recent    map[string][]sent
+	muted     map[string]time.Time
+	lastSweep time.Time
+}
+
+func newSpamDetector(window time.Duration, repeats int, links int, mute time.Duration) *spamDetector {
+	return &spamDetector{
+		window:  window,
+		repeats: repeats,
+		links:   links,
+		mute:    mute,
+		recent:  make(map[string][]sent),
+		muted:   make(map[string]time.Time),
+	}
+}
+
+// mutedFor returns how long uid is still muted.
+func (d *spamDetector) mutedFor(uid string, now time.Time) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.muted[uid].Sub(now)
+}
+
+// check records a message of uid and returns how long they are muted for because of it.
+func (d *spamDetector) check(uid string, text string, now time.Time) time.Duration {
+	if d.repeats <= 0 && d.links <= 0 {
+		return 0
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sweep(now)
+
+	msg := sent{
+		at:    now,
+		text:  strings.ToLower(strings.Join(strings.Fields(text), " ")),
+		links: len(linkPattern.FindAllString(text, -1)),
+	}
+	recent := append(d.drop(d.recent[uid], now), msg)
+	d.recent[uid] = recent
+
+	identical, links := 0, 0
+	for _, m := range recent {
+		if m.text != "" && m.text == msg.text {
+			identical++
+		}
+		links += m.links
+	}
+
+	if (d.repeats > 0 && identical >= d.repeats) || (d.links > 0 && links >= d.links) {
+		d.muted[uid] = now.Add(d.mute)
+		delete(d.recent, uid)
+		return d.mute
+	}
+	return 0
+}
+
+// drop removes the messages that left the window.
+func (d *spamDetector) drop(recent []sent, now time.Time) []sent {
+	i := 0
+	for i < len(recent) && now.Sub(recent[i].at) > d.window {
+		i++
+	}
+	return recent[i:]
+}
+
+// sweep forgets users who were quiet for a whole window and mutes that ended.
+// d.mu must be held by the caller.
+func (d *spamDetector) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < sweepInterval {
+		return
+	}
+	d.lastSweep = now
+	for uid, recent := range d.recent {
+		if recent = d.drop(recent, now); len(recent) == 0 {
+			delete(d.recent, uid)
+		} else {
+			d.recent[uid] = recent
+		}
+	}
+	for uid, until := range d.muted {
+		if !until.After(now) {
+			delete(d.muted, uid)
+		}
+	}
+}
+
+// guard applies the send limits of SendMessage. Its state is kept per replica,
+// a client spreading its calls over several replicas gets the limits of each.
+type guard struct {
+	users *limiter
+	rooms *limiter
+	spam  *spamDetector
+}
+
+func newGuard(opts Options) *guard {
+	return &guard{
+		users: newLimiter(opts.UserSendRate, opts.UserSendBurst),
+		rooms: newLimiter(opts.RoomSendRate, opts.RoomSendBurst),
+		spam:  newSpamDetector(opts.SpamWindow, opts.SpamRepeats, opts.SpamLinks, opts.MuteDuration),
+	}
+}
+
+// check decides whether uid may send text to roomID right now.
+func (g *guard) check(roomID string, uid string, text string) error {
+	now := time.Now()
+
+	if d := g.spam.mutedFor(uid, now); d > 0 {
+		return retryLater("user is muted for spamming", d)
+	}
+	if ok, d := g.users.allow(uid, now); !ok {
+		return retryLater("user is sending messages too fast", d)
+	}
+	if ok, d := g.rooms.allow(roomID, now); !ok {
+		return retryLater("chat room is receiving messages too fast", d)
+	}
+	if d := g.spam.check(uid, text, now); d > 0 {
+		return retryLater("user is muted for spamming", d)
+	}
+	return nil
+}
+
+// retryLater is a ResourceExhausted error telling the client when to try again.
+func retryLater(msg string, after time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(after)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
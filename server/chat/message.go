@@ -0,0 +1,213 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// encodePayload keeps the structured part of a message as protojson, nil for plain text messages.
+func encodePayload(msg *ChatMessage) ([]byte, error) {
+	if msg.Payload == nil {
+		return nil, nil
+	}
+	return protojson.Marshal(&ChatMessage{Payload: msg.Payload})
+}
+
+func decodePayload(data []byte, msg *ChatMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var stored ChatMessage
+	if err := protojson.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	msg.Payload = stored.Payload
+	return nil
+}
+
+// requestPayload turns the payload a client sent into the payload of the stored message.
+func requestPayload(req *ChatMessageRequest) (isChatMessage_Payload, error) {
+	switch payload := req.Payload.(type) {
+	case nil:
+		return nil, nil
+	case *ChatMessageRequest_Location:
+		return &ChatMessage_Location{Location: payload.Location}, nil
+	case *ChatMessageRequest_Pickup:
+		// whatever the client says, a new proposal waits for an answer
+		return &ChatMessage_Pickup{Pickup: &PickupProposal{
+			Start:  payload.Pickup.Start,
+			End:    payload.Pickup.End,
+			Status: PickupStatus_PICKUP_STATUS_PENDING,
+		}}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported message payload")
+	}
+}
+
+func getMessage(ctx context.Context, store ChatStore, leftoverID string, messageID string) (*ChatMessage, error) {
+	msg, err := store.GetMessage(ctx, leftoverID, messageID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "message not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get message: %v", err)
+	}
+	return msg, nil
+}
+
+func getHistory(ctx context.Context, store ChatStore, leftoverID string) ([]*ChatMessage, error) {
+	history := make([]*ChatMessage, 0)
+	err := store.History(ctx, leftoverID, nil, nil, func(msg *ChatMessage) error {
+		history = append(history, msg)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query chat history: %v", err)
+	}
+	return history, nil
+}
+
+// sendHistory replays the stored messages of a room to a freshly joined stream and returns their ids.
+// Messages broadcast since the user got a seat are queued for the stream too, the ids tell
+// which of them the replay already sent.
+func sendHistory(history []*ChatMessage, stream ChatService_JoinChatServer) (map[string]struct{}, error) {
+	replayed := make(map[string]struct{}, len(history))
+	for _, msg := range history {
+		if err := stream.Send(msg); err != nil {
+			return nil, err
+		}
+		replayed[msg.Id] = struct{}{}
+	}
+	return replayed, nil
+}
+
+// checkAuthorship makes sure that only the author can change a message and only within the edit window.
+func (s *ChatServer) checkAuthorship(msg *ChatMessage, uid string) error {
+	if msg.UserId != uid {
+		return status.Errorf(codes.PermissionDenied, "only the author can change a message")
+	}
+	if msg.Deleted {
+		return status.Errorf(codes.FailedPrecondition, "message is deleted")
+	}
+	if time.Since(msg.CreatedAt.AsTime()) > s.opts.EditWindow {
+		return status.Errorf(codes.FailedPrecondition, "message can only be changed within %s", s.opts.EditWindow)
+	}
+	return nil
+}
+
+// recipients returns who should hear about a message in a room: the owner and the guest, except the sender.
+func (s *ChatServer) recipients(ctx context.Context, leftoverID string, senderID string) ([]string, error) {
+	ownerID, err := leftoverOwner(ctx, s.store, leftoverID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []string{ownerID}
+	if room := s.hub.lookupRoom(leftoverID); room != nil {
+		room.mu.Lock()
+		if room.guestID != "" && room.guestID != ownerID {
+			users = append(users, room.guestID)
+		}
+		room.mu.Unlock()
+	}
+
+	return slices.DeleteFunc(users, func(uid string) bool { return uid == senderID }), nil
+}
+
+// storeMessage gives msg an id and timestamps and stores it,
+// queueing a notification for the recipients in case they are not connected.
+func (s *ChatServer) storeMessage(ctx context.Context, msg *ChatMessage) error {
+	recipients, err := s.recipients(ctx, msg.LeftoverId, msg.UserId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	msg.Id = uuid.NewString()
+	msg.CreatedAt = timestamppb.New(now)
+	msg.UpdatedAt = timestamppb.New(now)
+	msg.Event = ChatEvent_CHAT_EVENT_MESSAGE
+	if err := s.store.AddMessage(ctx, msg, recipients); err != nil {
+		return status.Errorf(codes.Internal, "failed to store message: %v", err)
+	}
+	return nil
+}
+
+func (s *ChatServer) EditMessage(ctx context.Context, req *EditMessageRequest) (*emptypb.Empty, error) {
+	msg, err := getMessage(ctx, s.store, req.LeftoverId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAuthorship(msg, req.UserId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	changed, err := s.store.EditMessage(ctx, req.MessageId, req.Message, req.Image, now)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to edit message: %v", err)
+	}
+	if !changed {
+		return nil, status.Errorf(codes.FailedPrecondition, "message is deleted")
+	}
+
+	msg.Message = req.Message
+	msg.Image = req.Image
+	msg.Edited = true
+	msg.UpdatedAt = timestamppb.New(now)
+	msg.Event = ChatEvent_CHAT_EVENT_EDITED
+	if err := s.hub.broadcast(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) DeleteMessage(ctx context.Context, req *DeleteMessageRequest) (*emptypb.Empty, error) {
+	msg, err := getMessage(ctx, s.store, req.LeftoverId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAuthorship(msg, req.UserId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	changed, err := s.store.DeleteMessage(ctx, req.MessageId, now)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete message: %v", err)
+	}
+	if !changed {
+		return nil, status.Errorf(codes.FailedPrecondition, "message is deleted")
+	}
+
+	msg.Message = ""
+	msg.Image = ""
+	msg.Deleted = true
+	msg.UpdatedAt = timestamppb.New(now)
+	msg.Event = ChatEvent_CHAT_EVENT_DELETED
+	if err := s.hub.broadcast(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// markDelivered records that msg reached a recipient's stream, which cancels its pending notification.
+func (h *hub) markDelivered(msg *ChatMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := h.store.MarkDelivered(ctx, msg.Id, time.Now().UTC()); err != nil {
+		h.logger.Error("failed to mark message as delivered", "message_id", msg.Id, "error", err)
+	}
+}
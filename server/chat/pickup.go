@@ -0,0 +1,130 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Decision names an answer to a proposal in the claim_decision notification of the proposer.
+func Decision(status PickupStatus) string {
+	if status == PickupStatus_PICKUP_STATUS_ACCEPTED {
+		return "accepted"
+	}
+	return "declined"
+}
+
+// canAnswerPickup decides who may answer a proposal: the owner answers the guest,
+// and a guest only answers the owner while they are seated in the room.
+func (s *ChatServer) canAnswerPickup(ctx context.Context, leftoverID string, proposerID string, uid string) (bool, error) {
+	if proposerID == uid {
+		return false, nil
+	}
+
+	isOwner, err := isUserOwner(ctx, s.store, uid, leftoverID)
+	if err != nil || isOwner {
+		return isOwner, err
+	}
+
+	proposedByOwner, err := isUserOwner(ctx, s.store, proposerID, leftoverID)
+	if err != nil || !proposedByOwner {
+		return false, err
+	}
+
+	room, err := s.hub.existingRoom(ctx, leftoverID)
+	if err != nil || room == nil {
+		return false, err
+	}
+	defer s.hub.evict(leftoverID, room)
+	return room.isSeated(uid), nil
+}
+
+func (s *ChatServer) RespondToPickup(ctx context.Context, req *PickupResponseRequest) (*emptypb.Empty, error) {
+	msg, err := getMessage(ctx, s.store, req.LeftoverId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	pickup := msg.GetPickup()
+	if pickup == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "message is not a pickup proposal")
+	}
+	if msg.Deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "message is deleted")
+	}
+	if pickup.Status != PickupStatus_PICKUP_STATUS_PENDING {
+		return nil, status.Errorf(codes.FailedPrecondition, "pickup proposal is already answered")
+	}
+
+	allowed, err := s.canAnswerPickup(ctx, req.LeftoverId, msg.UserId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "only the other side of the chat can answer a pickup proposal")
+	}
+
+	pickup.Status = PickupStatus_PICKUP_STATUS_DECLINED
+	notice := "Pickup proposal declined"
+	if req.Accept {
+		pickup.Status = PickupStatus_PICKUP_STATUS_ACCEPTED
+		notice = "Pickup proposal accepted"
+	}
+	now := time.Now().UTC()
+	answered, err := s.store.AnswerPickup(ctx, req.MessageId, pickup, req.UserId, now)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to answer pickup proposal: %v", err)
+	}
+	if !answered {
+		return nil, status.Errorf(codes.FailedPrecondition, "pickup proposal is already answered")
+	}
+
+	msg.UpdatedAt = timestamppb.New(now)
+	msg.Event = ChatEvent_CHAT_EVENT_UPDATED
+	if err := s.hub.broadcast(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	// leave a trace of the answer in the history as well
+	system := &ChatMessage{
+		LeftoverId: req.LeftoverId,
+		UserId:     req.UserId,
+		Message:    notice,
+		Payload:    &ChatMessage_Notice{Notice: &SystemNotice{Text: notice}},
+	}
+	if err := s.storeMessage(ctx, system); err != nil {
+		return nil, err
+	}
+	if err := s.hub.broadcast(ctx, system); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) GetAgreedPickup(ctx context.Context, req *AgreedPickupRequest) (*AgreedPickup, error) {
+	agreed, err := s.store.AgreedPickup(ctx, req.LeftoverId)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "no pickup agreed yet")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get agreed pickup: %v", err)
+	}
+
+	if req.UserId != agreed.ProposerId && req.UserId != agreed.AcceptedBy {
+		isOwner, err := isUserOwner(ctx, s.store, req.UserId, req.LeftoverId)
+		if err != nil {
+			return nil, err
+		}
+		if !isOwner {
+			return nil, status.Errorf(codes.PermissionDenied, "only the parties of the pickup can see it")
+		}
+	}
+
+	return agreed, nil
+}
@@ -0,0 +1,412 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	getOwnerQuery = `
+		SELECT owner_id
+		FROM leftover
+		WHERE id = $1;
+	`
+	ownedLeftoversQuery = `
+		SELECT id
+		FROM leftover
+		WHERE owner_id = $1;
+	`
+	// a user is refused in a room when the owner blocked them or banned them from that leftover
+	isRefusedQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_block b
+			JOIN leftover l ON l.owner_id = b.user_id
+			WHERE l.id = $1 AND b.blocked_user_id = $2
+		) OR EXISTS (
+			SELECT 1
+			FROM chat_ban
+			WHERE leftover_id = $1 AND user_id = $2
+		);
+	`
+	// everyone who ever talked in the room took part in it, not only the current guest
+	isParticipantQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM leftover
+			WHERE id = $1 AND owner_id = $2
+		) OR EXISTS (
+			SELECT 1
+			FROM chat_message
+			WHERE leftover_id = $1 AND user_id = $2
+		);
+	`
+	blockUserQuery = `
+		INSERT INTO user_block (user_id, blocked_user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;
+	`
+	unblockUserQuery = `
+		DELETE FROM user_block
+		WHERE user_id = $1 AND blocked_user_id = $2;
+	`
+	listBlockedQuery = `
+		SELECT blocked_user_id, created_at
+		FROM user_block
+		WHERE user_id = $1
+		ORDER BY created_at;
+	`
+	banUserQuery = `
+		INSERT INTO chat_ban (leftover_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;
+	`
+
+	// the message and the notifications for its recipients are written in one statement,
+	// the dispatcher drops the notification if the message is delivered or deleted in time
+	addMessageQuery = `
+		WITH msg AS (
+			INSERT INTO chat_message (id, leftover_id, user_id, message, image, payload, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+			RETURNING id, leftover_id, user_id, message
+		)
+		INSERT INTO notification_outbox (user_id, kind, subject_id, payload, created_at, next_attempt_at)
+		SELECT recipient, 'chat_message', msg.id, jsonb_build_object('leftover_id', msg.leftover_id, 'from', msg.user_id, 'text', msg.message), $7, $7
+		FROM msg, unnest($8::uuid[]) AS recipient;
+	`
+	markDeliveredQuery = `
+		UPDATE chat_message
+		SET delivered_at = $1
+		WHERE id = $2 AND delivered_at IS NULL;
+	`
+	// joining replays the whole history, so everything the other side sent is delivered by then
+	markHistoryDeliveredQuery = `
+		UPDATE chat_message
+		SET delivered_at = $1
+		WHERE leftover_id = $2 AND user_id <> $3 AND delivered_at IS NULL;
+	`
+	getMessageQuery = `
+		SELECT id, leftover_id, user_id, message, image, payload, edited, deleted_at IS NOT NULL, created_at, updated_at
+		FROM chat_message
+		WHERE id = $1 AND leftover_id = $2;
+	`
+	getHistoryQuery = `
+		SELECT id, leftover_id, user_id, message, image, payload, edited, deleted_at IS NOT NULL, created_at, updated_at
+		FROM chat_message
+		WHERE leftover_id = $1
+			AND ($2::timestamp IS NULL OR created_at >= $2)
+			AND ($3::timestamp IS NULL OR created_at < $3)
+		ORDER BY created_at;
+	`
+	editMessageQuery = `
+		UPDATE chat_message
+		SET message = $1, image = $2, edited = TRUE, updated_at = $3
+		WHERE id = $4 AND deleted_at IS NULL;
+	`
+	// deleted messages keep their row as a tombstone so clients can still place them in the history
+	deleteMessageQuery = `
+		UPDATE chat_message
+		SET message = '', image = '', payload = NULL, updated_at = $1, deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL;
+	`
+
+	// protojson leaves out the default PICKUP_STATUS_PENDING, so a pending proposal has no status
+	// the proposer is told about the answer in the same statement
+	answerPickupQuery = `
+		WITH answered AS (
+			UPDATE chat_message
+			SET payload = $1, updated_at = $2
+			WHERE id = $3 AND deleted_at IS NULL AND payload->'pickup'->>'status' IS NULL
+			RETURNING id, leftover_id, user_id
+		)
+		INSERT INTO notification_outbox (user_id, kind, subject_id, payload, created_at, next_attempt_at)
+		SELECT user_id, 'claim_decision', id,
+			jsonb_build_object('leftover_id', leftover_id, 'from', $4::uuid, 'status', $5::text, 'text', 'Your pickup proposal was ' || $5::text), $2, $2
+		FROM answered;
+	`
+	// accepting records the agreement too, the newest accepted proposal wins
+	acceptPickupQuery = `
+		WITH answered AS (
+			UPDATE chat_message
+			SET payload = $1, updated_at = $2
+			WHERE id = $3 AND deleted_at IS NULL AND payload->'pickup'->>'status' IS NULL
+			RETURNING id, leftover_id, user_id
+		), agreed AS (
+			INSERT INTO pickup_agreement (leftover_id, message_id, proposer_id, accepted_by, starts_at, ends_at, accepted_at)
+			SELECT leftover_id, id, user_id, $4, $6, $7, $2
+			FROM answered
+			ON CONFLICT (leftover_id) DO UPDATE
+			SET message_id = EXCLUDED.message_id, proposer_id = EXCLUDED.proposer_id, accepted_by = EXCLUDED.accepted_by,
+				starts_at = EXCLUDED.starts_at, ends_at = EXCLUDED.ends_at, accepted_at = EXCLUDED.accepted_at
+		)
+		INSERT INTO notification_outbox (user_id, kind, subject_id, payload, created_at, next_attempt_at)
+		SELECT user_id, 'claim_decision', id,
+			jsonb_build_object('leftover_id', leftover_id, 'from', $4::uuid, 'status', $5::text, 'text', 'Your pickup proposal was ' || $5::text), $2, $2
+		FROM answered;
+	`
+	getAgreedPickupQuery = `
+		SELECT message_id, proposer_id, accepted_by, starts_at, ends_at, accepted_at
+		FROM pickup_agreement
+		WHERE leftover_id = $1;
+	`
+
+	// a snapshot is only written when it is newer than the stored one,
+	// replicas may finish their writes in a different order than the events happened
+	saveRoomQuery = `
+		INSERT INTO chat_room (leftover_id, owner_id, guest_id, queue, saved_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (leftover_id) DO UPDATE
+		SET owner_id = EXCLUDED.owner_id, guest_id = EXCLUDED.guest_id, queue = EXCLUDED.queue, saved_at = EXCLUDED.saved_at
+		WHERE chat_room.saved_at <= EXCLUDED.saved_at;
+	`
+	loadRoomQuery = `
+		SELECT COALESCE(owner_id::text, ''), COALESCE(guest_id::text, ''), queue::text[], saved_at
+		FROM chat_room
+		WHERE leftover_id = $1;
+	`
+)
+
+type DatabaseInterface interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// PostgresChatStore keeps the chats in the chat tables, next to the leftovers
+// and the notification outbox it reads and writes as well.
+type PostgresChatStore struct {
+	db DatabaseInterface
+}
+
+func NewPostgresChatStore(db DatabaseInterface) *PostgresChatStore {
+	return &PostgresChatStore{
+		db: db,
+	}
+}
+
+func (s *PostgresChatStore) LeftoverOwner(ctx context.Context, leftoverID string) (string, error) {
+	var ownerID string
+	err := s.db.QueryRow(ctx, getOwnerQuery, leftoverID).Scan(&ownerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return ownerID, err
+}
+
+func (s *PostgresChatStore) OwnedLeftovers(ctx context.Context, ownerID string) ([]string, error) {
+	rows, err := s.db.Query(ctx, ownedLeftoversQuery, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (s *PostgresChatStore) IsRefused(ctx context.Context, leftoverID string, userID string) (bool, error) {
+	var refused bool
+	err := s.db.QueryRow(ctx, isRefusedQuery, leftoverID, userID).Scan(&refused)
+	return refused, err
+}
+
+func (s *PostgresChatStore) IsParticipant(ctx context.Context, leftoverID string, userID string) (bool, error) {
+	var participant bool
+	err := s.db.QueryRow(ctx, isParticipantQuery, leftoverID, userID).Scan(&participant)
+	return participant, err
+}
+
+func (s *PostgresChatStore) Block(ctx context.Context, userID string, blockedUserID string) error {
+	_, err := s.db.Exec(ctx, blockUserQuery, userID, blockedUserID)
+	return err
+}
+
+func (s *PostgresChatStore) Unblock(ctx context.Context, userID string, blockedUserID string) error {
+	_, err := s.db.Exec(ctx, unblockUserQuery, userID, blockedUserID)
+	return err
+}
+
+func (s *PostgresChatStore) ListBlocked(ctx context.Context, userID string) ([]*BlockedUser, error) {
+	rows, err := s.db.Query(ctx, listBlockedQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*BlockedUser, 0)
+	for rows.Next() {
+		var (
+			blockedID string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&blockedID, &createdAt); err != nil {
+			return nil, err
+		}
+		items = append(items, &BlockedUser{
+			UserId:    blockedID,
+			CreatedAt: timestamppb.New(createdAt),
+		})
+	}
+	return items, rows.Err()
+}
+
+func (s *PostgresChatStore) Ban(ctx context.Context, leftoverID string, userID string) error {
+	_, err := s.db.Exec(ctx, banUserQuery, leftoverID, userID)
+	return err
+}
+
+func scanMessage(row pgx.Row) (*ChatMessage, error) {
+	var (
+		msg                  ChatMessage
+		payload              []byte
+		createdAt, updatedAt time.Time
+	)
+	err := row.Scan(&msg.Id, &msg.LeftoverId, &msg.UserId, &msg.Message, &msg.Image, &payload, &msg.Edited, &msg.Deleted, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodePayload(payload, &msg); err != nil {
+		return nil, err
+	}
+	msg.CreatedAt = timestamppb.New(createdAt)
+	msg.UpdatedAt = timestamppb.New(updatedAt)
+	return &msg, nil
+}
+
+func (s *PostgresChatStore) AddMessage(ctx context.Context, msg *ChatMessage, recipients []string) error {
+	payload, err := encodePayload(msg)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(ctx, addMessageQuery, msg.Id, msg.LeftoverId, msg.UserId, msg.Message, msg.Image, payload, msg.CreatedAt.AsTime(), recipients)
+	return err
+}
+
+func (s *PostgresChatStore) GetMessage(ctx context.Context, leftoverID string, messageID string) (*ChatMessage, error) {
+	msg, err := scanMessage(s.db.QueryRow(ctx, getMessageQuery, messageID, leftoverID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return msg, err
+}
+
+// History calls fn while it reads the rows, a long chat does not have to fit in memory.
+func (s *PostgresChatStore) History(ctx context.Context, leftoverID string, from *time.Time, to *time.Time, fn func(msg *ChatMessage) error) error {
+	rows, err := s.db.Query(ctx, getHistoryQuery, leftoverID, from, to)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *PostgresChatStore) EditMessage(ctx context.Context, messageID string, message string, image string, at time.Time) (bool, error) {
+	tag, err := s.db.Exec(ctx, editMessageQuery, message, image, at, messageID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *PostgresChatStore) DeleteMessage(ctx context.Context, messageID string, at time.Time) (bool, error) {
+	tag, err := s.db.Exec(ctx, deleteMessageQuery, at, messageID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *PostgresChatStore) MarkDelivered(ctx context.Context, messageID string, at time.Time) error {
+	_, err := s.db.Exec(ctx, markDeliveredQuery, at, messageID)
+	return err
+}
+
+func (s *PostgresChatStore) MarkHistoryDelivered(ctx context.Context, leftoverID string, userID string, at time.Time) error {
+	_, err := s.db.Exec(ctx, markHistoryDeliveredQuery, at, leftoverID, userID)
+	return err
+}
+
+func (s *PostgresChatStore) AnswerPickup(ctx context.Context, messageID string, pickup *PickupProposal, answeredBy string, at time.Time) (bool, error) {
+	payload, err := encodePayload(&ChatMessage{Payload: &ChatMessage_Pickup{Pickup: pickup}})
+	if err != nil {
+		return false, err
+	}
+
+	var tag pgconn.CommandTag
+	if pickup.Status == PickupStatus_PICKUP_STATUS_ACCEPTED {
+		tag, err = s.db.Exec(ctx, acceptPickupQuery, payload, at, messageID, answeredBy, Decision(pickup.Status), pickup.Start.AsTime(), pickup.End.AsTime())
+	} else {
+		tag, err = s.db.Exec(ctx, answerPickupQuery, payload, at, messageID, answeredBy, Decision(pickup.Status))
+	}
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *PostgresChatStore) AgreedPickup(ctx context.Context, leftoverID string) (*AgreedPickup, error) {
+	var (
+		agreed                       AgreedPickup
+		startsAt, endsAt, acceptedAt time.Time
+	)
+	err := s.db.QueryRow(ctx, getAgreedPickupQuery, leftoverID).Scan(
+		&agreed.MessageId, &agreed.ProposerId, &agreed.AcceptedBy, &startsAt, &endsAt, &acceptedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	agreed.LeftoverId = leftoverID
+	agreed.Start = timestamppb.New(startsAt)
+	agreed.End = timestamppb.New(endsAt)
+	agreed.AcceptedAt = timestamppb.New(acceptedAt)
+	return &agreed, nil
+}
+
+func nullable(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+func (s *PostgresChatStore) SaveRoom(ctx context.Context, roomID string, snap RoomSnapshot) error {
+	_, err := s.db.Exec(ctx, saveRoomQuery, roomID, nullable(snap.OwnerID), nullable(snap.GuestID), snap.Queue, snap.SavedAt)
+	return err
+}
+
+func (s *PostgresChatStore) LoadRoom(ctx context.Context, roomID string) (*RoomSnapshot, error) {
+	var snap RoomSnapshot
+	err := s.db.QueryRow(ctx, loadRoomQuery, roomID).Scan(&snap.OwnerID, &snap.GuestID, &snap.Queue, &snap.SavedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
@@ -0,0 +1,91 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by a ChatStore when the leftover, message or agreement does not exist.
+var ErrNotFound = errors.New("chat: not found")
+
+// ChatStore keeps the chats. PostgresChatStore is the production store,
+// storage.MemoryStore keeps everything in memory for development.
+type ChatStore interface {
+	LeftoverOwner(ctx context.Context, leftoverID string) (string, error)
+	OwnedLeftovers(ctx context.Context, ownerID string) ([]string, error)
+	// IsRefused reports whether the owner of the leftover blocked userID or banned them from its room.
+	IsRefused(ctx context.Context, leftoverID string, userID string) (bool, error)
+	// IsParticipant reports whether userID owns the leftover or ever wrote in its room.
+	IsParticipant(ctx context.Context, leftoverID string, userID string) (bool, error)
+
+	Block(ctx context.Context, userID string, blockedUserID string) error
+	Unblock(ctx context.Context, userID string, blockedUserID string) error
+	ListBlocked(ctx context.Context, userID string) ([]*BlockedUser, error)
+	Ban(ctx context.Context, leftoverID string, userID string) error
+
+	// AddMessage stores msg, which already has its id and timestamps, and queues
+	// a chat notification for each recipient in the same write.
+	AddMessage(ctx context.Context, msg *ChatMessage, recipients []string) error
+	GetMessage(ctx context.Context, leftoverID string, messageID string) (*ChatMessage, error)
+	// History calls fn with the messages of a room in the order they were sent,
+	// from and to bound their creation time unless they are nil.
+	History(ctx context.Context, leftoverID string, from *time.Time, to *time.Time, fn func(msg *ChatMessage) error) error
+	// EditMessage and DeleteMessage report false when the message is already deleted.
+	EditMessage(ctx context.Context, messageID string, message string, image string, at time.Time) (bool, error)
+	DeleteMessage(ctx context.Context, messageID string, at time.Time) (bool, error)
+	MarkDelivered(ctx context.Context, messageID string, at time.Time) error
+	// MarkHistoryDelivered applies to the messages userID got from the other side.
+	MarkHistoryDelivered(ctx context.Context, leftoverID string, userID string, at time.Time) error
+
+	// AnswerPickup stores the answer to a pending proposal, records the agreement when it is accepted
+	// and queues a claim_decision notification for the proposer.
+	// It reports false when the proposal was answered or deleted in the meantime.
+	AnswerPickup(ctx context.Context, messageID string, pickup *PickupProposal, answeredBy string, at time.Time) (bool, error)
+	AgreedPickup(ctx context.Context, leftoverID string) (*AgreedPickup, error)
+
+	// SaveRoom keeps snap unless a newer snapshot is stored already.
+	SaveRoom(ctx context.Context, roomID string, snap RoomSnapshot) error
+	// LoadRoom returns the stored snapshot of a room, or nil if nothing was stored.
+	LoadRoom(ctx context.Context, roomID string) (*RoomSnapshot, error)
+}
+
+// RoomSnapshot is the part of a room that survives a restart.
+type RoomSnapshot struct {
+	OwnerID string
+	GuestID string
+	Queue   []string
+	SavedAt time.Time
+}
+
+// snapshot copies the shared state of the room. room.mu must be held by the caller.
+func (room *room) snapshot(at time.Time) RoomSnapshot {
+	return RoomSnapshot{
+		OwnerID: room.ownerID,
+		GuestID: room.guestID,
+		Queue:   append([]string{}, room.queue...),
+		SavedAt: at,
+	}
+}
+
+// restore fills a fresh room from its snapshot. Nobody is connected after a restart,
+// so every seat and queue position is held until the restore window ends.
+// room.mu must be held by the caller.
+func (h *hub) restore(room *room, roomID string, snap *RoomSnapshot) {
+	remaining := h.opts.RestoreWindow - time.Since(snap.SavedAt)
+	if remaining <= 0 {
+		h.logger.Info("stored room is too old, starting fresh", "leftover_id", roomID, "saved_at", snap.SavedAt)
+		return
+	}
+
+	room.ownerID = snap.OwnerID
+	room.guestID = snap.GuestID
+	room.queue = snap.Queue
+
+	for _, uid := range append([]string{snap.OwnerID, snap.GuestID}, snap.Queue...) {
+		if uid != "" {
+			h.hold(room, roomID, uid, remaining)
+		}
+	}
+	h.logger.Info("room restored", "leftover_id", roomID, "owner_id", room.ownerID, "guest_id", room.guestID, "queued", len(room.queue), "window", remaining)
+}
@@ -0,0 +1,161 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// transcriptEntry is a message in the JSON lines transcript.
+type transcriptEntry struct {
+	ID        string          `json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Author    string          `json:"author"`
+	Message   string          `json:"message,omitempty"`
+	Image     string          `json:"image,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Edited    bool            `json:"edited,omitempty"`
+	Deleted   bool            `json:"deleted,omitempty"`
+}
+
+// conversation returns the messages of a room uid may export, nil means all of them.
+// Admins and the owner get the whole room. A guest only gets their own conversations with the owner:
+// their messages and the replies of the owner until another guest writes, the room seats one guest at a time.
+func (s *ChatServer) conversation(ctx context.Context, leftoverID string, uid string) (func(msg *ChatMessage) bool, error) {
+	if slices.Contains(s.opts.Admins, uid) {
+		return nil, nil
+	}
+
+	ownerID, err := leftoverOwner(ctx, s.store, leftoverID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID == uid {
+		return nil, nil
+	}
+
+	participant, err := s.store.IsParticipant(ctx, leftoverID, uid)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check participants: %v", err)
+	}
+	if !participant {
+		return nil, status.Errorf(codes.PermissionDenied, "only participants of the chat and admins can export it")
+	}
+
+	// the owner talks to the guest who wrote last
+	talkingTo := ""
+	return func(msg *ChatMessage) bool {
+		if msg.UserId != ownerID {
+			talkingTo = msg.UserId
+		}
+		return talkingTo == uid
+	}, nil
+}
+
+func (s *ChatServer) ExportTranscript(req *ExportTranscriptRequest, stream ChatService_ExportTranscriptServer) error {
+	ctx := stream.Context()
+
+	include, err := s.conversation(ctx, req.LeftoverId, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	var from, to *time.Time
+	if req.From != nil {
+		t := req.From.AsTime()
+		from = &t
+	}
+	if req.To != nil {
+		t := req.To.AsTime()
+		to = &t
+	}
+	// the conversation of a guest may have started before from, so the filter reads the room from the start
+	readFrom := from
+	if include != nil {
+		readFrom = nil
+	}
+
+	// messages are sent as they are read, a long chat does not have to fit in memory
+	err = s.store.History(ctx, req.LeftoverId, readFrom, to, func(msg *ChatMessage) error {
+		if include != nil && (!include(msg) || (from != nil && msg.CreatedAt.AsTime().Before(*from))) {
+			return nil
+		}
+		if req.Format == TranscriptFormat_TRANSCRIPT_FORMAT_TEXT {
+			return stream.Send(&TranscriptLine{Line: transcriptText(msg)})
+		}
+		line, err := transcriptJSON(msg)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to encode chat message: %v", err)
+		}
+		return stream.Send(&TranscriptLine{Line: line})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "failed to query chat history: %v", err)
+	}
+
+	return nil
+}
+
+func transcriptJSON(msg *ChatMessage) (string, error) {
+	payload, err := encodePayload(msg)
+	if err != nil {
+		return "", err
+	}
+
+	line, err := json.Marshal(transcriptEntry{
+		ID:        msg.Id,
+		CreatedAt: msg.CreatedAt.AsTime(),
+		UpdatedAt: msg.UpdatedAt.AsTime(),
+		Author:    msg.UserId,
+		Message:   msg.Message,
+		Image:     msg.Image,
+		Payload:   payload,
+		Edited:    msg.Edited,
+		Deleted:   msg.Deleted,
+	})
+	return string(line), err
+}
+
+// transcriptText renders a message as "<time> <author>: <text>" with its attachments in brackets.
+func transcriptText(msg *ChatMessage) string {
+	head := fmt.Sprintf("%s %s:", msg.CreatedAt.AsTime().Format(time.RFC3339), msg.UserId)
+	if msg.Deleted {
+		return head + " [deleted]"
+	}
+
+	parts := []string{head}
+	if msg.Message != "" {
+		parts = append(parts, msg.Message)
+	}
+	switch payload := msg.Payload.(type) {
+	case *ChatMessage_Location:
+		point := payload.Location.GetPoint()
+		parts = append(parts, fmt.Sprintf("[location %s %.6f,%.6f]", payload.Location.Label, point.GetLatitude(), point.GetLongitude()))
+	case *ChatMessage_Pickup:
+		parts = append(parts, fmt.Sprintf("[pickup %s - %s, %s]",
+			payload.Pickup.Start.AsTime().Format(time.RFC3339),
+			payload.Pickup.End.AsTime().Format(time.RFC3339),
+			strings.ToLower(strings.TrimPrefix(payload.Pickup.Status.String(), "PICKUP_STATUS_"))))
+	case *ChatMessage_Notice:
+		if msg.Message != payload.Notice.Text {
+			parts = append(parts, fmt.Sprintf("[notice %s]", payload.Notice.Text))
+		}
+	}
+	if msg.Image != "" {
+		parts = append(parts, fmt.Sprintf("[image %s]", msg.Image))
+	}
+	if msg.Edited {
+		parts = append(parts, "(edited)")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,74 @@
+package chat_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"lovco/server/chat"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// export returns the text transcript uid gets.
+func export(s *chat.ChatServer, uid string) ([]string, error) {
+	st := newStream[chat.TranscriptLine](context.Background())
+	req := &chat.ExportTranscriptRequest{LeftoverId: leftoverID, UserId: uid, Format: chat.TranscriptFormat_TRANSCRIPT_FORMAT_TEXT}
+	if err := s.ExportTranscript(req, st); err != nil {
+		return nil, err
+	}
+	close(st.sent)
+
+	var texts []string
+	for line := range st.sent {
+		// "<time> <author>: <text>"
+		_, text, _ := strings.Cut(line.Line, ": ")
+		texts = append(texts, text)
+	}
+	return texts, nil
+}
+
+func TestGuestsExportTheirOwnConversations(t *testing.T) {
+	s := newServer(t, newStore(t), chat.Options{})
+
+	ownerClient := join(s, owner, nil)
+	defer ownerClient.cancel()
+	eventually(t, "the owner is seated", seated(s, owner))
+	first := join(s, guest, nil)
+	eventually(t, "the guest is seated", func() bool { return send(s, guest, "first guest") == nil })
+	if err := send(s, owner, "reply to first"); err != nil {
+		t.Fatal(err)
+	}
+
+	first.cancel()
+	first.ended(t)
+	second := join(s, other, nil)
+	defer second.cancel()
+	eventually(t, "the next guest is seated", func() bool { return send(s, other, "second guest") == nil })
+	if err := send(s, owner, "reply to second"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		uid  string
+		want []string
+	}{
+		{owner, []string{"hello", "first guest", "reply to first", "second guest", "reply to second"}},
+		{guest, []string{"first guest", "reply to first"}},
+		{other, []string{"second guest", "reply to second"}},
+	}
+	for _, tt := range tests {
+		got, err := export(s, tt.uid)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.uid, err)
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s exported %q, want %q", tt.uid, got, tt.want)
+		}
+	}
+
+	if _, err := export(s, stranger); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("a user who never wrote exported the room: %v, want PermissionDenied", err)
+	}
+}
@@ -0,0 +1,78 @@
+package chat
+
+import (
+	"lovco/server/validate"
+)
+
+// maxMessageLen is the longest text of a chat message in characters.
+const maxMessageLen = 4096
+
+// AddRules declares the rules the requests of the chat service must follow.
+func AddRules(rules validate.Rules) {
+	room := []validate.Rule{validate.ID("leftover_id"), validate.ID("user_id")}
+	roomAnd := func(more ...validate.Rule) []validate.Rule {
+		return append(append([]validate.Rule{}, room...), more...)
+	}
+
+	rules.Add(&JoinChatRequest{}, room...)
+	rules.Add(&EndChatRequest{}, room...)
+	rules.Add(&AgreedPickupRequest{}, room...)
+
+	rules.Add(&ChatMessageRequest{}, roomAnd(
+		validate.MaxLen("message", maxMessageLen),
+		validate.MaxLen("image", 255),
+		validate.Check(func(req *ChatMessageRequest, v *validate.Violations) {
+			if req.Message == "" && req.Image == "" && req.Payload == nil {
+				v.Add("message", validate.ReasonRequired, "needs a text, an image, a location or a pickup proposal")
+			}
+		}),
+		validate.Required("location.point"),
+		validate.Between("location.point.latitude", -90, 90),
+		validate.Between("location.point.longitude", -180, 180),
+		validate.MaxLen("location.label", 255),
+		validate.Required("pickup.start"),
+		validate.Required("pickup.end"),
+		validate.Check(func(req *ChatMessageRequest, v *validate.Violations) {
+			start, end := req.GetPickup().GetStart(), req.GetPickup().GetEnd()
+			if start != nil && end != nil && !end.AsTime().After(start.AsTime()) {
+				v.Add("pickup.end", validate.ReasonInvalidRange, "must be after the start")
+			}
+		}),
+	)...)
+
+	rules.Add(&EditMessageRequest{}, roomAnd(
+		validate.ID("message_id"),
+		validate.MaxLen("message", maxMessageLen),
+		validate.MaxLen("image", 255),
+	)...)
+	rules.Add(&DeleteMessageRequest{}, roomAnd(validate.ID("message_id"))...)
+	rules.Add(&PickupResponseRequest{}, roomAnd(validate.ID("message_id"))...)
+
+	rules.Add(&BlockUserRequest{},
+		validate.ID("user_id"),
+		validate.ID("blocked_user_id"),
+		validate.Check(func(req *BlockUserRequest, v *validate.Violations) {
+			if req.UserId != "" && req.UserId == req.BlockedUserId {
+				v.Add("blocked_user_id", validate.ReasonConflict, "users cannot block themselves")
+			}
+		}),
+	)
+	rules.Add(&ListBlockedRequest{}, validate.ID("user_id"))
+
+	rules.Add(&BanUserRequest{}, roomAnd(
+		validate.ID("owner_id"),
+		validate.Check(func(req *BanUserRequest, v *validate.Violations) {
+			if req.UserId != "" && req.UserId == req.OwnerId {
+				v.Add("user_id", validate.ReasonConflict, "owners cannot ban themselves")
+			}
+		}),
+	)...)
+
+	rules.Add(&ExportTranscriptRequest{}, roomAnd(
+		validate.Check(func(req *ExportTranscriptRequest, v *validate.Violations) {
+			if req.From != nil && req.To != nil && !req.To.AsTime().After(req.From.AsTime()) {
+				v.Add("to", validate.ReasonInvalidRange, "must be after from")
+			}
+		}),
+	)...)
+}
@@ -4,156 +4,116 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"log"
 	"log/slog"
-	"lovco/server/chat"
+	"lovco/server"
 	"lovco/server/config"
-	"lovco/server/leftover"
-	"net"
+	"lovco/server/logging"
+	"lovco/server/migrate"
+	"lovco/server/pubsub"
+	"lovco/server/tracing"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
-	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
-	healthpb "google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/peer"
-	"google.golang.org/grpc/reflection"
-	"google.golang.org/grpc/status"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var (
-	port    = flag.Int("port", 50051, "The server port")
-	address = flag.String("address", "0.0.0.0", "The server address")
+var printConfig = flag.Bool("print-config", false, "Print the effective configuration with the secrets redacted and exit")
 
-	sleep  = flag.Duration("sleep", 10*time.Minute, "The sleep time in minutes")
-	system = ""
-)
-
-type server struct {
-	grpc.ServerStream
-	recv int
-	sent int
-}
-
-func (s *server) RecvMsg(m any) error {
-	slog.Info("Receive a message", "type", fmt.Sprintf("%T", m), "time", time.Now().Format(time.RFC3339))
-	s.recv++
-	return s.ServerStream.RecvMsg(m)
-}
-
-func (s *server) SendMsg(m any) error {
-	slog.Info("Send a message", "type", fmt.Sprintf("%T", m), "time", time.Now().Format(time.RFC3339))
-	s.sent++
-	return s.ServerStream.SendMsg(m)
-}
-
-func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		start := time.Now()
-		var peerAddr string
-		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
-			peerAddr = p.Addr.String()
-		}
-		logger.Info("grpc unary start", "method", info.FullMethod, "peer", peerAddr)
-
-		resp, err := handler(ctx, req)
-		st, _ := status.FromError(err)
-
-		logger.Info("grpc unary end", "method", info.FullMethod, "code", st.Code().String(), "duration", time.Since(start), "peer", peerAddr, "err", err)
-		return resp, err
+func main() {
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
-}
-
-func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
-	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		start := time.Now()
-		var peerAddr string
-		if p, ok := peer.FromContext(ss.Context()); ok && p.Addr != nil {
-			peerAddr = p.Addr.String()
-		}
-		wrapped := &server{ServerStream: ss}
-		logger.Info(
-			"grpc stream start",
-			"method", info.FullMethod,
-			"peer", peerAddr,
-			"client_stream", info.IsClientStream,
-			"server_stream", info.IsServerStream,
-		)
-		err := handler(srv, wrapped)
-		st, _ := status.FromError(err)
-		logger.Info(
-			"grpc stream end",
-			"method", info.FullMethod,
-			"code", st.Code().String(),
-			"duration", time.Since(start),
-			"peer", peerAddr,
-			"recv", wrapped.recv,
-			"sent", wrapped.sent,
-			"err", err,
-		)
-		return err
+	if *printConfig {
+		fmt.Print(cfg)
+		return
 	}
-}
-
-func main() {
-	flag.Parse()
-
-	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	config.InitDB(logger)
-	defer config.DB.Close()
-
-	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", *address, *port))
+	logger, err := logging.New(os.Stdout, cfg.Log.Format, cfg.Log.Level)
 	if err != nil {
-		slog.Error("Failed to listen", "error", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
+	// packages without a logger of their own log the same way
+	slog.SetDefault(logger)
 
-	srv := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(loggingUnaryInterceptor(logger)),
-		grpc.ChainStreamInterceptor(loggingStreamInterceptor(logger)),
-	)
-
-	reflection.Register(srv)
-
-	healthcheck := health.NewServer()
-	healthgrpc.RegisterHealthServer(srv, healthcheck)
+	if flag.Arg(0) == "migrate" {
+		os.Exit(runMigrate(cfg, flag.Args()[1:], logger))
+	}
 
-	leftoverServer := leftover.NewLeftoverServer(config.DB)
-	leftover.RegisterLeftoverServiceServer(srv, leftoverServer)
+	logger.Info("Effective configuration", "config", cfg)
 
-	chatServer := chat.NewChatServer(config.DB)
-	chat.RegisterChatServiceServer(srv, chatServer)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.Tracing.Exporter,
+		File:        cfg.Tracing.File,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: "lovco",
+	})
+	if err != nil {
+		logger.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	// the spans of the last calls are flushed after the server stopped
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("Failed to flush the traces", "error", err)
+		}
+	}()
 
-	go func() {
-		next := healthpb.HealthCheckResponse_SERVING
-		for {
-			healthcheck.SetServingStatus(system, next)
-			healthcheck.SetServingStatus("", next)
+	opts := []server.Option{
+		server.WithConfig(cfg),
+		server.WithLogger(logger),
+	}
 
-			if next == healthpb.HealthCheckResponse_SERVING {
-				next = healthpb.HealthCheckResponse_NOT_SERVING
-			} else {
-				next = healthpb.HealthCheckResponse_SERVING
+	var db *pgxpool.Pool
+	switch cfg.Server.Storage {
+	case "memory":
+		logger.Warn("Keeping everything in memory, nothing survives a restart")
+		opts = append(opts, server.WithMemoryStore())
+	case "postgres":
+		db, err = config.OpenDB(context.Background(), cfg.Database, logger)
+		if err != nil {
+			logger.Error("Unable to connect to database", "error", err)
+			os.Exit(1)
+		}
+		defer func() {
+			db.Close()
+			logger.Info("Database connections closed")
+		}()
+
+		if cfg.Server.Migrate {
+			migrator, err := migrate.New(db, logger)
+			if err == nil {
+				err = migrator.Up(context.Background())
+			}
+			if err != nil {
+				logger.Error("Failed to migrate the database", "error", err)
+				os.Exit(1)
 			}
-
-			time.Sleep(*sleep)
 		}
-	}()
+		opts = append(opts, server.WithDB(db))
+	}
 
-	go func() {
-		if err := srv.Serve(lis); err != nil {
-			log.Fatalf("failed to serve: %v", err)
-		}
-	}()
+	if cfg.Chat.PubSub == "postgres" {
+		opts = append(opts, server.WithBroker(pubsub.NewPostgresBroker(db, logger)))
+	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	slog.Info("Shutting down server...")
+	srv, err := server.New(opts...)
+	if err != nil {
+		logger.Error("Failed to start the server", "error", err)
+		os.Exit(1)
+	}
 
-	srv.GracefulStop()
-	slog.Info("Server gracefully stopped")
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := srv.Serve(ctx); err != nil {
+		logger.Error("Server stopped", "error", err)
+	}
 }
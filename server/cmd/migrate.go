@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"lovco/server/config"
+	"lovco/server/migrate"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = `Usage: lovco migrate <command>
+
+Commands:
+  up        apply every pending migration
+  down      revert the newest applied migration
+  status    list the migrations and whether they are applied
+  to N      apply or revert migrations until version N is the newest applied one,
+            the baseline version 1 cannot be reverted
+`
+
+// runMigrate handles the migrate subcommand and returns the exit code.
+func runMigrate(cfg *config.Config, args []string, logger *slog.Logger) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	if cfg.Server.Storage != "postgres" {
+		fmt.Fprintf(os.Stderr, "there is nothing to migrate with storage %s\n", cfg.Server.Storage)
+		return 2
+	}
+
+	db, err := config.OpenDB(context.Background(), cfg.Database, logger)
+	if err != nil {
+		logger.Error("Unable to connect to database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db, logger)
+	if err != nil {
+		logger.Error("Failed to load migrations", "error", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = migrator.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		err = migrator.Down(ctx)
+	case args[0] == "status" && len(args) == 1:
+		err = printStatus(ctx, migrator)
+	case args[0] == "to" && len(args) == 2:
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil {
+			fmt.Fprintf(os.Stderr, "invalid version %q\n\n%s", args[1], migrateUsage)
+			return 2
+		}
+		err = migrator.To(ctx, version)
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if err != nil {
+		logger.Error("Migration failed", "error", err)
+		return 1
+	}
+	return 0
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, st := range statuses {
+		state, appliedAt := "pending", ""
+		if st.Applied {
+			state = "applied"
+			appliedAt = st.AppliedAt.Format(time.RFC3339)
+		}
+		if st.Modified {
+			state = "modified"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", st.Version, st.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,575 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"lovco/server/logging"
+	"lovco/server/notification"
+	"lovco/server/tracing"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets when the configuration is printed.
+const redacted = "REDACTED"
+
+// sslModes are the libpq SSL modes pgx supports.
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Config is the configuration of the server. It is loaded by Load from, in increasing
+// order of precedence, the defaults, an optional YAML file, environment variables and flags.
+type Config struct {
+	Server   Server   `yaml:"server"`
+	Log      Log      `yaml:"log"`
+	Database Database `yaml:"database"`
+	Health   Health   `yaml:"health"`
+	Chat     Chat     `yaml:"chat"`
+	Notify   Notify   `yaml:"notify"`
+	Webhook  Webhook  `yaml:"webhook"`
+	Metrics  Metrics  `yaml:"metrics"`
+	Tracing  Tracing  `yaml:"tracing"`
+}
+
+// Server is the gRPC listener and its connection handling.
+// Storage memory keeps everything in memory instead of the database, for local development.
+// CrashDir, when set, gets a report file for every handler panic.
+type Server struct {
+	Address          string        `yaml:"address"`
+	Port             int           `yaml:"port"`
+	Storage          string        `yaml:"storage"`
+	Migrate          bool          `yaml:"migrate"`
+	DrainTimeout     time.Duration `yaml:"drain_timeout"`
+	KeepaliveTime    time.Duration `yaml:"keepalive_time"`
+	KeepaliveTimeout time.Duration `yaml:"keepalive_timeout"`
+	KeepaliveMinTime time.Duration `yaml:"keepalive_min_time"`
+	CrashDir         string        `yaml:"crash_dir"`
+}
+
+// Log is the format, text or json, and the minimum level of the log lines.
+type Log struct {
+	Format string `yaml:"format"`
+	Level  string `yaml:"level"`
+}
+
+// Database is the PostgreSQL connection. URL, when set, replaces the host, port, user,
+// password, name and SSL settings. Password and the password in URL are never printed.
+// ConnectTimeout bounds a single connection attempt, the startup keeps retrying for ConnectRetryFor.
+// MaxConns of 0 keeps the pgx default and StatementTimeout of 0 lets statements run for as long as they take.
+type Database struct {
+	URL              string        `yaml:"url"`
+	Host             string        `yaml:"host"`
+	Port             int           `yaml:"port"`
+	User             string        `yaml:"user"`
+	Password         string        `yaml:"password"`
+	Name             string        `yaml:"name"`
+	SSLMode          string        `yaml:"sslmode"`
+	SSLRootCert      string        `yaml:"sslrootcert"`
+	SSLCert          string        `yaml:"sslcert"`
+	SSLKey           string        `yaml:"sslkey"`
+	ConnectTimeout   time.Duration `yaml:"connect_timeout"`
+	ConnectRetryFor  time.Duration `yaml:"connect_retry_for"`
+	MaxConns         int           `yaml:"max_conns"`
+	MinConns         int           `yaml:"min_conns"`
+	MaxConnLifetime  time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime  time.Duration `yaml:"max_conn_idle_time"`
+	StatementTimeout time.Duration `yaml:"statement_timeout"`
+}
+
+// Health tunes the dependency checks behind the gRPC health service.
+type Health struct {
+	Interval time.Duration `yaml:"interval"`
+	Timeout  time.Duration `yaml:"timeout"`
+	Failures int           `yaml:"failures"`
+}
+
+// Chat tunes the chat rooms, their queues and the send limits.
+type Chat struct {
+	PubSub         string        `yaml:"pubsub"`
+	Admins         []string      `yaml:"admins"`
+	EditWindow     time.Duration `yaml:"edit_window"`
+	ReconnectGrace time.Duration `yaml:"reconnect_grace"`
+	RestoreWindow  time.Duration `yaml:"restore_window"`
+	MaxQueue       int           `yaml:"max_queue"`
+	MaxQueueWait   time.Duration `yaml:"max_queue_wait"`
+	Heartbeat      time.Duration `yaml:"heartbeat"`
+	SendRate       float64       `yaml:"send_rate"`
+	SendBurst      int           `yaml:"send_burst"`
+	RoomSendRate   float64       `yaml:"room_send_rate"`
+	RoomSendBurst  int           `yaml:"room_send_burst"`
+	SpamWindow     time.Duration `yaml:"spam_window"`
+	SpamRepeats    int           `yaml:"spam_repeats"`
+	SpamLinks      int           `yaml:"spam_links"`
+	Mute           time.Duration `yaml:"mute"`
+}
+
+// Notify tunes the notification dispatcher.
+type Notify struct {
+	Interval    time.Duration `yaml:"interval"`
+	BatchWindow time.Duration `yaml:"batch_window"`
+	Channel     string        `yaml:"channel"`
+	SMTPAddr    string        `yaml:"smtp_addr"`
+	SMTPFrom    string        `yaml:"smtp_from"`
+}
+
+// Webhook tunes the webhook dispatcher.
+type Webhook struct {
+	Interval            time.Duration `yaml:"interval"`
+	MaxAttempts         int           `yaml:"max_attempts"`
+	AllowPrivateTargets bool          `yaml:"allow_private_targets"`
+}
+
+// Metrics is the HTTP listener serving /metrics for Prometheus, an empty address turns it off.
+type Metrics struct {
+	Address string `yaml:"address"`
+}
+
+// Tracing sends OpenTelemetry spans to an exporter: none, stdout, otlp-file writing OTLP JSON
+// lines to File, or otlp to the collector at Endpoint. SampleRatio is the share of the traces
+// started here that are kept, calls that come with a trace context follow the caller.
+type Tracing struct {
+	Exporter    string  `yaml:"exporter"`
+	File        string  `yaml:"file"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Default returns the configuration used when nothing else is set.
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Address:          "0.0.0.0",
+			Port:             50051,
+			Storage:          "postgres",
+			DrainTimeout:     10 * time.Second,
+			KeepaliveTime:    time.Minute,
+			KeepaliveTimeout: 20 * time.Second,
+			KeepaliveMinTime: 15 * time.Second,
+		},
+		Log: Log{
+			Format: logging.FormatText,
+			Level:  "info",
+		},
+		Database: Database{
+			Host:            "localhost",
+			Port:            5432,
+			Name:            "lovco",
+			SSLMode:         "prefer",
+			ConnectTimeout:  5 * time.Second,
+			ConnectRetryFor: 30 * time.Second,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+		},
+		Health: Health{
+			Interval: 10 * time.Second,
+			Timeout:  3 * time.Second,
+			Failures: 3,
+		},
+		Chat: Chat{
+			PubSub:         "memory",
+			EditWindow:     15 * time.Minute,
+			ReconnectGrace: 30 * time.Second,
+			RestoreWindow:  15 * time.Minute,
+			MaxQueue:       20,
+			MaxQueueWait:   30 * time.Minute,
+			Heartbeat:      30 * time.Second,
+			SendRate:       1,
+			SendBurst:      5,
+			RoomSendRate:   3,
+			RoomSendBurst:  10,
+			SpamWindow:     time.Minute,
+			SpamRepeats:    3,
+			SpamLinks:      5,
+			Mute:           5 * time.Minute,
+		},
+		Notify: Notify{
+			Interval:    10 * time.Second,
+			BatchWindow: 2 * time.Minute,
+			Channel:     notification.ChannelLog,
+			SMTPAddr:    "localhost:1025",
+			SMTPFrom:    "noreply@lovco.local",
+		},
+		Webhook: Webhook{
+			Interval:    5 * time.Second,
+			MaxAttempts: 10,
+		},
+		Metrics: Metrics{
+			Address: "0.0.0.0:9090",
+		},
+		Tracing: Tracing{
+			Exporter:    tracing.ExporterNone,
+			File:        "traces.jsonl",
+			Endpoint:    "localhost:4317",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// setting is a configuration value that can be set by a flag and an environment variable.
+// field returns a pointer to the value in c.
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	field func(c *Config) any
+}
+
+// settings are every value that can be set besides the file. The database keeps
+// the variable names it had before, everything else is prefixed with LOVCO_.
+var settings = []setting{
+	{"address", "LOVCO_ADDRESS", "The server address", func(c *Config) any { return &c.Server.Address }},
+	{"port", "LOVCO_PORT", "The server port", func(c *Config) any { return &c.Server.Port }},
+	{"storage", "LOVCO_STORAGE", "Storage backend: postgres, or memory to run without a database, nothing is kept across restarts", func(c *Config) any { return &c.Server.Storage }},
+	{"migrate", "LOVCO_MIGRATE", "Apply pending database migrations before serving, replicas starting together take turns", func(c *Config) any { return &c.Server.Migrate }},
+	{"drain-timeout", "LOVCO_DRAIN_TIMEOUT", "How long open calls may take to finish on shutdown before they are cut off", func(c *Config) any { return &c.Server.DrainTimeout }},
+	{"keepalive-time", "LOVCO_KEEPALIVE_TIME", "How long a connection may be idle before the server pings the client", func(c *Config) any { return &c.Server.KeepaliveTime }},
+	{"keepalive-timeout", "LOVCO_KEEPALIVE_TIMEOUT", "How long the server waits for a ping answer before closing the connection", func(c *Config) any { return &c.Server.KeepaliveTimeout }},
+	{"keepalive-min-time", "LOVCO_KEEPALIVE_MIN_TIME", "Minimum time between client pings, clients pinging more often are disconnected", func(c *Config) any { return &c.Server.KeepaliveMinTime }},
+	{"crash-dir", "LOVCO_CRASH_DIR", "Directory a report file is written to for every handler panic, empty for none", func(c *Config) any { return &c.Server.CrashDir }},
+
+	{"log-format", "LOVCO_LOG_FORMAT", "Log format: text or json", func(c *Config) any { return &c.Log.Format }},
+	{"log-level", "LOVCO_LOG_LEVEL", "Minimum log level: debug, info, warn or error", func(c *Config) any { return &c.Log.Level }},
+
+	{"db-url", "DATABASE_URL", "Database connection URL, replaces the other connection and SSL settings", func(c *Config) any { return &c.Database.URL }},
+	{"db-host", "DB_HOST", "Database host", func(c *Config) any { return &c.Database.Host }},
+	{"db-port", "DB_PORT", "Database port", func(c *Config) any { return &c.Database.Port }},
+	{"db-user", "DB_USER", "Database user", func(c *Config) any { return &c.Database.User }},
+	{"db-password", "DB_PASSWORD", "Database password, prefer the environment so it does not show up in the process list", func(c *Config) any { return &c.Database.Password }},
+	{"db-name", "DB_NAME", "Database name", func(c *Config) any { return &c.Database.Name }},
+	{"db-sslmode", "DB_SSLMODE", "Database SSL mode: disable, allow, prefer, require, verify-ca or verify-full", func(c *Config) any { return &c.Database.SSLMode }},
+	{"db-sslrootcert", "DB_SSLROOTCERT", "CA certificate file the database server certificate is verified with", func(c *Config) any { return &c.Database.SSLRootCert }},
+	{"db-sslcert", "DB_SSLCERT", "Client certificate file for the database", func(c *Config) any { return &c.Database.SSLCert }},
+	{"db-sslkey", "DB_SSLKEY", "Client key file for the database", func(c *Config) any { return &c.Database.SSLKey }},
+	{"db-connect-timeout", "DB_CONNECT_TIMEOUT", "How long a single attempt to connect to the database may take", func(c *Config) any { return &c.Database.ConnectTimeout }},
+	{"db-connect-retry-for", "DB_CONNECT_RETRY_FOR", "How long the startup keeps retrying to reach the database, 0 to try once", func(c *Config) any { return &c.Database.ConnectRetryFor }},
+	{"db-max-conns", "DB_MAX_CONNS", "Maximum open database connections, 0 for the pgx default", func(c *Config) any { return &c.Database.MaxConns }},
+	{"db-min-conns", "DB_MIN_CONNS", "Database connections kept open when idle", func(c *Config) any { return &c.Database.MinConns }},
+	{"db-max-conn-lifetime", "DB_MAX_CONN_LIFETIME", "How long a database connection is used before it is replaced", func(c *Config) any { return &c.Database.MaxConnLifetime }},
+	{"db-max-conn-idle-time", "DB_MAX_CONN_IDLE_TIME", "How long an idle database connection is kept", func(c *Config) any { return &c.Database.MaxConnIdleTime }},
+	{"db-statement-timeout", "DB_STATEMENT_TIMEOUT", "How long a database statement may run before it is cancelled, 0 for no limit", func(c *Config) any { return &c.Database.StatementTimeout }},
+
+	{"health-interval", "LOVCO_HEALTH_INTERVAL", "How often the database and the other dependencies are checked", func(c *Config) any { return &c.Health.Interval }},
+	{"health-timeout", "LOVCO_HEALTH_TIMEOUT", "How long a single health check may take", func(c *Config) any { return &c.Health.Timeout }},
+	{"health-failures", "LOVCO_HEALTH_FAILURES", "Consecutive failed checks before a service is reported NOT_SERVING", func(c *Config) any { return &c.Health.Failures }},
+
+	{"pubsub", "LOVCO_PUBSUB", "Chat room pub/sub backend: memory for a single node, postgres to share rooms between replicas", func(c *Config) any { return &c.Chat.PubSub }},
+	{"chat-admins", "LOVCO_CHAT_ADMINS", "Comma separated ids of the users allowed to export any chat transcript", func(c *Config) any { return &c.Chat.Admins }},
+	{"edit-window", "LOVCO_EDIT_WINDOW", "How long a chat message can be edited or deleted by its author", func(c *Config) any { return &c.Chat.EditWindow }},
+	{"reconnect-grace", "LOVCO_RECONNECT_GRACE", "How long a chat seat is held for a disconnected user", func(c *Config) any { return &c.Chat.ReconnectGrace }},
+	{"restore-window", "LOVCO_RESTORE_WINDOW", "How long chat seats and queue positions are kept for users returning after a restart", func(c *Config) any { return &c.Chat.RestoreWindow }},
+	{"max-queue", "LOVCO_MAX_QUEUE", "Maximum number of users waiting for a chat room, 0 for no limit", func(c *Config) any { return &c.Chat.MaxQueue }},
+	{"max-queue-wait", "LOVCO_MAX_QUEUE_WAIT", "Maximum time a user waits in a chat queue, 0 for no limit", func(c *Config) any { return &c.Chat.MaxQueueWait }},
+	{"heartbeat", "LOVCO_HEARTBEAT", "How often idle chat streams get a heartbeat, keep it below the proxy idle timeout", func(c *Config) any { return &c.Chat.Heartbeat }},
+	{"send-rate", "LOVCO_SEND_RATE", "Chat messages per second a user may send on average, 0 for no limit", func(c *Config) any { return &c.Chat.SendRate }},
+	{"send-burst", "LOVCO_SEND_BURST", "Chat messages a user may send in a burst", func(c *Config) any { return &c.Chat.SendBurst }},
+	{"room-send-rate", "LOVCO_ROOM_SEND_RATE", "Chat messages per second a room may receive on average, 0 for no limit", func(c *Config) any { return &c.Chat.RoomSendRate }},
+	{"room-send-burst", "LOVCO_ROOM_SEND_BURST", "Chat messages a room may receive in a burst", func(c *Config) any { return &c.Chat.RoomSendBurst }},
+	{"spam-window", "LOVCO_SPAM_WINDOW", "Window in which repeated messages and links are counted", func(c *Config) any { return &c.Chat.SpamWindow }},
+	{"spam-repeats", "LOVCO_SPAM_REPEATS", "Identical messages within the spam window that mute a user, 0 to disable", func(c *Config) any { return &c.Chat.SpamRepeats }},
+	{"spam-links", "LOVCO_SPAM_LINKS", "Links within the spam window that mute a user, 0 to disable", func(c *Config) any { return &c.Chat.SpamLinks }},
+	{"mute", "LOVCO_MUTE", "How long a spamming user is muted", func(c *Config) any { return &c.Chat.Mute }},
+
+	{"notify-interval", "LOVCO_NOTIFY_INTERVAL", "How often pending notifications are sent", func(c *Config) any { return &c.Notify.Interval }},
+	{"notify-batch-window", "LOVCO_NOTIFY_BATCH_WINDOW", "How long notifications wait to be batched, messages delivered or read meanwhile are not notified", func(c *Config) any { return &c.Notify.BatchWindow }},
+	{"notify-channel", "LOVCO_NOTIFY_CHANNEL", "Notification channel for users without a preference", func(c *Config) any { return &c.Notify.Channel }},
+	{"smtp-addr", "LOVCO_SMTP_ADDR", "SMTP relay for email notifications, e.g. MailHog", func(c *Config) any { return &c.Notify.SMTPAddr }},
+	{"smtp-from", "LOVCO_SMTP_FROM", "Sender address of email notifications", func(c *Config) any { return &c.Notify.SMTPFrom }},
+
+	{"webhook-interval", "LOVCO_WEBHOOK_INTERVAL", "How often pending webhook deliveries are posted, also the base of the retry backoff", func(c *Config) any { return &c.Webhook.Interval }},
+	{"webhook-max-attempts", "LOVCO_WEBHOOK_MAX_ATTEMPTS", "Failed attempts after which a webhook delivery is dead-lettered", func(c *Config) any { return &c.Webhook.MaxAttempts }},
+	{"webhook-allow-private-targets", "LOVCO_WEBHOOK_ALLOW_PRIVATE_TARGETS", "Post webhooks and webhook notifications to loopback, private and link-local addresses too, only for development", func(c *Config) any { return &c.Webhook.AllowPrivateTargets }},
+
+	{"metrics-address", "LOVCO_METRICS_ADDRESS", "host:port serving Prometheus metrics on /metrics, empty to turn them off", func(c *Config) any { return &c.Metrics.Address }},
+
+	{"tracing-exporter", "LOVCO_TRACING_EXPORTER", "Where OpenTelemetry spans go: none, stdout, otlp-file or otlp", func(c *Config) any { return &c.Tracing.Exporter }},
+	{"tracing-file", "LOVCO_TRACING_FILE", "File the otlp-file exporter appends OTLP JSON lines to", func(c *Config) any { return &c.Tracing.File }},
+	{"tracing-endpoint", "LOVCO_TRACING_ENDPOINT", "host:port of the OTLP gRPC collector of the otlp exporter", func(c *Config) any { return &c.Tracing.Endpoint }},
+	{"tracing-insecure", "LOVCO_TRACING_INSECURE", "Talk to the OTLP collector without TLS", func(c *Config) any { return &c.Tracing.Insecure }},
+	{"tracing-sample-ratio", "LOVCO_TRACING_SAMPLE_RATIO", "Share of the traces started by the server that are recorded, from 0 to 1", func(c *Config) any { return &c.Tracing.SampleRatio }},
+}
+
+// Load registers the configuration flags on fs, parses args and returns the validated configuration.
+// The file is read from the -config flag or the LOVCO_CONFIG variable, a .env file in the
+// working directory is loaded into the environment first. Flags the caller registered on fs
+// before are parsed too, the remaining arguments are left in fs.Args().
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	// flags are parsed into their own copy, only the ones given on the command line are applied last
+	flagged := Default()
+	file := fs.String("config", "", "Path of a YAML configuration file, overrides the defaults and is overridden by the environment and flags")
+	for _, s := range settings {
+		usage := fmt.Sprintf("%s (env %s)", s.usage, s.env)
+		switch v := s.field(flagged).(type) {
+		case *string:
+			fs.StringVar(v, s.flag, *v, usage)
+		case *int:
+			fs.IntVar(v, s.flag, *v, usage)
+		case *bool:
+			fs.BoolVar(v, s.flag, *v, usage)
+		case *float64:
+			fs.Float64Var(v, s.flag, *v, usage)
+		case *time.Duration:
+			fs.DurationVar(v, s.flag, *v, usage)
+		case *[]string:
+			fs.Func(s.flag, usage, func(value string) error {
+				*v = splitList(value)
+				return nil
+			})
+		default:
+			panic(fmt.Sprintf("config: setting %s has unsupported type %T", s.flag, v))
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	path := *file
+	if path == "" {
+		path = os.Getenv("LOVCO_CONFIG")
+	}
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	_ = godotenv.Load()
+	if err := cfg.readEnv(); err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, s := range settings {
+		if set[s.flag] {
+			reflect.ValueOf(s.field(cfg)).Elem().Set(reflect.ValueOf(s.field(flagged)).Elem())
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) readEnv() error {
+	for _, s := range settings {
+		value, ok := os.LookupEnv(s.env)
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch v := s.field(c).(type) {
+		case *string:
+			*v = value
+		case *int:
+			*v, err = strconv.Atoi(value)
+		case *bool:
+			*v, err = strconv.ParseBool(value)
+		case *float64:
+			*v, err = strconv.ParseFloat(value, 64)
+		case *time.Duration:
+			*v, err = time.ParseDuration(value)
+		case *[]string:
+			*v = splitList(value)
+		}
+		if err != nil {
+			return fmt.Errorf("config: invalid %s %q: %w", s.env, value, err)
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma or space separated list.
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// Validate reports every invalid value at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	positive := func(name string, d time.Duration) {
+		check(d > 0, "%s must be positive, got %s", name, d)
+	}
+	notNegative := func(name string, d time.Duration) {
+		check(d >= 0, "%s must not be negative, got %s", name, d)
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port must be between 1 and 65535, got %d", c.Server.Port)
+	positive("server.drain_timeout", c.Server.DrainTimeout)
+	positive("server.keepalive_time", c.Server.KeepaliveTime)
+	positive("server.keepalive_timeout", c.Server.KeepaliveTimeout)
+	notNegative("server.keepalive_min_time", c.Server.KeepaliveMinTime)
+	check(slices.Contains([]string{"memory", "postgres"}, c.Server.Storage), "server.storage must be memory or postgres, got %q", c.Server.Storage)
+	if c.Server.Storage == "memory" {
+		// nothing else is shared between replicas without a database
+		check(!c.Server.Migrate, "server.migrate needs server.storage postgres")
+		check(c.Chat.PubSub == "memory", "chat.pubsub %s needs server.storage postgres", c.Chat.PubSub)
+	}
+
+	check(slices.Contains(logging.Formats, c.Log.Format), "log.format must be one of %s, got %q", strings.Join(logging.Formats, ", "), c.Log.Format)
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+
+	if c.Database.URL == "" && c.Server.Storage != "memory" {
+		check(c.Database.Host != "", "database.host is required (DB_HOST) unless database.url is set")
+		check(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port must be between 1 and 65535, got %d", c.Database.Port)
+		check(c.Database.User != "", "database.user is required (DB_USER) unless database.url is set")
+		check(c.Database.Name != "", "database.name is required (DB_NAME) unless database.url is set")
+		check(slices.Contains(sslModes, c.Database.SSLMode), "database.sslmode must be one of %s, got %q", strings.Join(sslModes, ", "), c.Database.SSLMode)
+		check((c.Database.SSLCert == "") == (c.Database.SSLKey == ""), "database.sslcert and database.sslkey must be set together")
+		for name, file := range map[string]string{"sslrootcert": c.Database.SSLRootCert, "sslcert": c.Database.SSLCert, "sslkey": c.Database.SSLKey} {
+			if file != "" {
+				_, err := os.Stat(file)
+				check(err == nil, "database.%s: %v", name, err)
+			}
+		}
+	}
+	positive("database.connect_timeout", c.Database.ConnectTimeout)
+	notNegative("database.connect_retry_for", c.Database.ConnectRetryFor)
+	check(c.Database.MaxConns >= 0, "database.max_conns must not be negative, got %d", c.Database.MaxConns)
+	check(c.Database.MinConns >= 0, "database.min_conns must not be negative, got %d", c.Database.MinConns)
+	check(c.Database.MaxConns == 0 || c.Database.MinConns <= c.Database.MaxConns, "database.min_conns (%d) must not exceed database.max_conns (%d)", c.Database.MinConns, c.Database.MaxConns)
+	positive("database.max_conn_lifetime", c.Database.MaxConnLifetime)
+	positive("database.max_conn_idle_time", c.Database.MaxConnIdleTime)
+	notNegative("database.statement_timeout", c.Database.StatementTimeout)
+
+	positive("health.interval", c.Health.Interval)
+	positive("health.timeout", c.Health.Timeout)
+	check(c.Health.Failures >= 1, "health.failures must be at least 1, got %d", c.Health.Failures)
+
+	check(slices.Contains([]string{"memory", "postgres"}, c.Chat.PubSub), "chat.pubsub must be memory or postgres, got %q", c.Chat.PubSub)
+	notNegative("chat.edit_window", c.Chat.EditWindow)
+	notNegative("chat.reconnect_grace", c.Chat.ReconnectGrace)
+	notNegative("chat.restore_window", c.Chat.RestoreWindow)
+	check(c.Chat.MaxQueue >= 0, "chat.max_queue must not be negative, got %d", c.Chat.MaxQueue)
+	notNegative("chat.max_queue_wait", c.Chat.MaxQueueWait)
+	notNegative("chat.heartbeat", c.Chat.Heartbeat)
+	check(c.Chat.SendRate >= 0, "chat.send_rate must not be negative, got %g", c.Chat.SendRate)
+	check(c.Chat.SendBurst >= 1, "chat.send_burst must be at least 1, got %d", c.Chat.SendBurst)
+	check(c.Chat.RoomSendRate >= 0, "chat.room_send_rate must not be negative, got %g", c.Chat.RoomSendRate)
+	check(c.Chat.RoomSendBurst >= 1, "chat.room_send_burst must be at least 1, got %d", c.Chat.RoomSendBurst)
+	positive("chat.spam_window", c.Chat.SpamWindow)
+	check(c.Chat.SpamRepeats >= 0, "chat.spam_repeats must not be negative, got %d", c.Chat.SpamRepeats)
+	check(c.Chat.SpamLinks >= 0, "chat.spam_links must not be negative, got %d", c.Chat.SpamLinks)
+	positive("chat.mute", c.Chat.Mute)
+
+	positive("notify.interval", c.Notify.Interval)
+	notNegative("notify.batch_window", c.Notify.BatchWindow)
+	channels := []string{notification.ChannelLog, notification.ChannelEmail, notification.ChannelWebhook}
+	check(slices.Contains(channels, c.Notify.Channel), "notify.channel must be one of %s, got %q", strings.Join(channels, ", "), c.Notify.Channel)
+	check(c.Notify.Channel != notification.ChannelEmail || c.Notify.SMTPAddr != "", "notify.smtp_addr is required for the email channel")
+
+	positive("webhook.interval", c.Webhook.Interval)
+	check(c.Webhook.MaxAttempts >= 1, "webhook.max_attempts must be at least 1, got %d", c.Webhook.MaxAttempts)
+
+	if c.Metrics.Address != "" {
+		_, _, err := net.SplitHostPort(c.Metrics.Address)
+		check(err == nil, "metrics.address must be host:port, got %q", c.Metrics.Address)
+	}
+
+	check(slices.Contains(tracing.Exporters, c.Tracing.Exporter), "tracing.exporter must be one of %s, got %q", strings.Join(tracing.Exporters, ", "), c.Tracing.Exporter)
+	check(c.Tracing.Exporter != tracing.ExporterFile || c.Tracing.File != "", "tracing.file is required for the otlp-file exporter")
+	check(c.Tracing.Exporter != tracing.ExporterOTLP || c.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Redacted returns a copy of c that is safe to print.
+func (c *Config) Redacted() *Config {
+	r := *c
+	r.Chat.Admins = slices.Clone(c.Chat.Admins)
+	if r.Database.Password != "" {
+		r.Database.Password = redacted
+	}
+	r.Database.URL = redactURL(c.Database.URL)
+	return &r
+}
+
+// redactURL replaces the password of a connection URL. Connection strings that
+// are not URLs are replaced whole, they may hold a password anywhere.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return redacted
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	if q := u.Query(); q.Has("password") {
+		q.Set("password", redacted)
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// String is the effective configuration as YAML with the secrets redacted.
+func (c *Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("config: %v", err)
+	}
+	return string(out)
+}
+
+// LogValue logs the configuration as a group per section with the secrets redacted.
+func (c *Config) LogValue() slog.Value {
+	return logValue(reflect.ValueOf(c.Redacted()).Elem())
+}
+
+func logValue(v reflect.Value) slog.Value {
+	if v.Kind() != reflect.Struct {
+		if d, ok := v.Interface().(time.Duration); ok {
+			return slog.StringValue(d.String())
+		}
+		return slog.AnyValue(v.Interface())
+	}
+
+	attrs := make([]slog.Attr, 0, v.NumField())
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+		attrs = append(attrs, slog.Attr{Key: name, Value: logValue(v.Field(i))})
+	}
+	return slog.GroupValue(attrs...)
+}
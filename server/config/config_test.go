@@ -0,0 +1,135 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// load runs Load in an empty directory, so no .env file of the developer gets in,
+// with the memory storage, which needs no database settings.
+func load(t *testing.T, args ...string) (*Config, error) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	t.Setenv("LOVCO_STORAGE", "memory")
+	fs := flag.NewFlagSet("lovco", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return Load(fs, args)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lovco.yaml")
+	file := `
+chat:
+    heartbeat: 10s
+webhook:
+    interval: 7s
+    max_attempts: 3
+`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LOVCO_CONFIG", path)
+	t.Setenv("LOVCO_WEBHOOK_INTERVAL", "8s")
+	t.Setenv("LOVCO_WEBHOOK_MAX_ATTEMPTS", "4")
+
+	cfg, err := load(t, "-webhook-max-attempts", "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"default", cfg.Notify.Interval, 10 * time.Second},
+		{"file over default", cfg.Chat.Heartbeat, 10 * time.Second},
+		{"env over file", cfg.Webhook.Interval, 8 * time.Second},
+		{"flag over env", cfg.Webhook.MaxAttempts, 5},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadFlagDefaultDoesNotOverride(t *testing.T) {
+	t.Setenv("LOVCO_HEARTBEAT", "12s")
+
+	cfg, err := load(t, "-webhook-interval", "6s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Chat.Heartbeat != 12*time.Second {
+		t.Errorf("heartbeat = %s, want the 12s of the environment", cfg.Chat.Heartbeat)
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{"unparsable env", map[string]string{"LOVCO_HEARTBEAT": "soon"}, nil, "LOVCO_HEARTBEAT"},
+		{"invalid value", nil, []string{"-heartbeat", "-1s"}, "chat.heartbeat"},
+		{"unknown flag", nil, []string{"-no-such-flag"}, "no-such-flag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := load(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one about %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		url      string
+		wantURL  string
+	}{
+		{"empty", "", "", ""},
+		{"url without password", "", "postgres://lovco@db:5432/lovco", "postgres://lovco@db:5432/lovco"},
+		{"url password", "", "postgres://lovco:hunter2@db:5432/lovco", "postgres://lovco:REDACTED@db:5432/lovco"},
+		{"query password", "", "postgres://db/lovco?password=hunter2", "postgres://db/lovco?password=REDACTED"},
+		{"key value string", "", "host=db password=hunter2", "REDACTED"},
+		{"password field", "hunter2", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Database.Password = tt.password
+			cfg.Database.URL = tt.url
+
+			r := cfg.Redacted()
+			if r.Database.URL != tt.wantURL {
+				t.Errorf("url = %q, want %q", r.Database.URL, tt.wantURL)
+			}
+			if tt.password != "" && r.Database.Password != redacted {
+				t.Errorf("password = %q, want it redacted", r.Database.Password)
+			}
+			if cfg.Database.Password != tt.password || cfg.Database.URL != tt.url {
+				t.Error("Redacted changed the configuration it was called on")
+			}
+			for _, out := range []string{cfg.String(), cfg.LogValue().String()} {
+				if strings.Contains(out, "hunter2") {
+					t.Errorf("printed configuration has the password: %s", out)
+				}
+			}
+		})
+	}
+}
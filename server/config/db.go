@@ -2,40 +2,139 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"os"
+	"lovco/server/tracing"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/joho/godotenv"
 )
 
-var DB *pgxpool.Pool
+// Backoff between the connection attempts on startup, doubled after every failure.
+const (
+	minConnectBackoff = 500 * time.Millisecond
+	maxConnectBackoff = 10 * time.Second
+)
 
-func InitDB(logger *slog.Logger) {
-	_ = godotenv.Load()
+// OpenDB connects to the database of cfg and pings it until it answers or
+// cfg.ConnectRetryFor passed. The caller closes the pool.
+func OpenDB(ctx context.Context, cfg Database, logger *slog.Logger) (*pgxpool.Pool, error) {
+	poolConfig, err := cfg.poolConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
 
-	dsn := fmt.Sprintf(
-		"user=%s password=%s host=%s port=%s dbname=%s sslmode=disable",
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_NAME"),
+	// only the parsed fields are logged, the connection string may hold the password
+	conn := poolConfig.ConnConfig
+	logger.Info("Connecting to the database",
+		"host", conn.Host,
+		"port", conn.Port,
+		"name", conn.Database,
+		"user", conn.User,
+		"tls", conn.TLSConfig != nil,
+		"max_conns", poolConfig.MaxConns,
 	)
 
-	logger.Info("Connecting to the database", "dsn", dsn)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create database pool: %w", err)
+	}
+	if err := ping(ctx, pool, cfg, logger); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	logger.Info("Connected to the database")
+	return pool, nil
+}
+
+// ping retries with an exponential backoff, the database may still be starting.
+// Rejected credentials are not retried, they will not fix themselves.
+func ping(ctx context.Context, pool *pgxpool.Pool, cfg Database, logger *slog.Logger) error {
+	deadline := time.Now().Add(cfg.ConnectRetryFor)
+	backoff := minConnectBackoff
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+		err := pool.Ping(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, "28") {
+			return fmt.Errorf("database rejected the credentials: %w", err)
+		}
+
+		wait := min(backoff, time.Until(deadline))
+		if wait <= 0 {
+			return fmt.Errorf("unable to connect to database after %d attempts: %w", attempt, err)
+		}
+		logger.Warn("Database is not reachable yet, retrying", "attempt", attempt, "retry_in", wait, "error", err)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
 
-	dbpool, err := pgxpool.New(ctx, dsn)
+func (d Database) poolConfig() (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(d.connString())
 	if err != nil {
-		logger.Error("Unable to connect to database", "error", err)
-		os.Exit(1)
+		return nil, err
 	}
 
-	DB = dbpool
-	logger.Info("Connected to the database")
+	if d.MaxConns > 0 {
+		poolConfig.MaxConns = int32(d.MaxConns)
+	}
+	poolConfig.MinConns = int32(d.MinConns)
+	poolConfig.MaxConnLifetime = d.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = d.MaxConnIdleTime
+	poolConfig.ConnConfig.ConnectTimeout = d.ConnectTimeout
+	poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+	if d.StatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(d.StatementTimeout.Milliseconds(), 10)
+	}
+	return poolConfig, nil
+}
+
+// connString is URL when it is set, otherwise a key/value connection string of the other settings.
+func (d Database) connString() string {
+	if d.URL != "" {
+		return d.URL
+	}
+
+	params := [][2]string{
+		{"host", d.Host},
+		{"port", strconv.Itoa(d.Port)},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+		{"sslrootcert", d.SSLRootCert},
+		{"sslcert", d.SSLCert},
+		{"sslkey", d.SSLKey},
+	}
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if p[1] != "" {
+			parts = append(parts, p[0]+"="+quoteParam(p[1]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteParam quotes a key/value connection string value, passwords may hold spaces and quotes.
+func quoteParam(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
 }
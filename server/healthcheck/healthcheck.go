@@ -0,0 +1,160 @@
+package healthcheck
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Service names reported besides the gRPC services.
+// Liveness is SERVING while the process runs, orchestrators restart it when it is not.
+// Readiness, also reported as the empty service name, is SERVING once every check passes
+// and until the shutdown starts, orchestrators only route calls to ready replicas.
+const (
+	Liveness  = "liveness"
+	Readiness = "readiness"
+)
+
+// Check reports whether a dependency, e.g. the database, works.
+type Check func(ctx context.Context) error
+
+// Options tunes the monitor.
+// Interval is how often the checks run and Timeout how long a single check may take.
+// A check has to fail FailureThreshold times in a row before the services depending on it
+// are NOT_SERVING, a single success makes them SERVING again.
+type Options struct {
+	Interval         time.Duration
+	Timeout          time.Duration
+	FailureThreshold int
+}
+
+// Monitor runs the checks and keeps the statuses of a gRPC health server up to date.
+type Monitor struct {
+	server   *health.Server
+	opts     Options
+	logger   *slog.Logger
+	checks   map[string]Check
+	services map[string][]string // service name -> checks it depends on
+
+	mu           sync.Mutex
+	failures     map[string]int
+	started      bool
+	shuttingDown bool
+}
+
+func NewMonitor(server *health.Server, opts Options, logger *slog.Logger) *Monitor {
+	m := &Monitor{
+		server:   server,
+		opts:     opts,
+		logger:   logger,
+		checks:   make(map[string]Check),
+		services: make(map[string][]string),
+		failures: make(map[string]int),
+	}
+
+	// not ready until the first round of checks passed
+	server.SetServingStatus(Liveness, healthpb.HealthCheckResponse_SERVING)
+	server.SetServingStatus(Readiness, healthpb.HealthCheckResponse_NOT_SERVING)
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return m
+}
+
+// AddCheck registers a named check. Call it before Run.
+func (m *Monitor) AddCheck(name string, check Check) {
+	m.checks[name] = check
+}
+
+// AddService reports service as SERVING only while the named checks pass. Call it before Run.
+func (m *Monitor) AddService(service string, checks ...string) {
+	m.services[service] = checks
+	m.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Run checks right away and then every interval until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.checkAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports the replica as not ready for good, liveness stays SERVING while it drains.
+func (m *Monitor) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shuttingDown = true
+
+	for service := range m.services {
+		m.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	m.server.SetServingStatus(Readiness, healthpb.HealthCheckResponse_NOT_SERVING)
+	m.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func (m *Monitor) checkAll(ctx context.Context) {
+	results := make(map[string]error, len(m.checks))
+	for name, check := range m.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, m.opts.Timeout)
+		results[name] = check(checkCtx)
+		cancel()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.shuttingDown || ctx.Err() != nil {
+		return
+	}
+
+	healthy := make(map[string]bool, len(results))
+	for name, err := range results {
+		if err == nil {
+			if m.failures[name] >= m.opts.FailureThreshold {
+				m.logger.Info("health check recovered", "check", name)
+			}
+			m.failures[name] = 0
+			healthy[name] = true
+			continue
+		}
+
+		m.failures[name]++
+		m.logger.Warn("health check failed", "check", name, "failures", m.failures[name], "error", err)
+		// before the first success a single failure is enough, the replica was never ready
+		healthy[name] = m.started && m.failures[name] < m.opts.FailureThreshold
+	}
+
+	ready := true
+	for service, checks := range m.services {
+		serving := true
+		for _, name := range checks {
+			serving = serving && healthy[name]
+		}
+		ready = ready && serving
+		m.server.SetServingStatus(service, servingStatus(serving))
+	}
+	for _, ok := range healthy {
+		ready = ready && ok
+	}
+
+	m.started = m.started || ready
+	m.server.SetServingStatus(Readiness, servingStatus(ready))
+	m.server.SetServingStatus("", servingStatus(ready))
+}
+
+func servingStatus(ok bool) healthpb.HealthCheckResponse_ServingStatus {
+	if ok {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"lovco/server/logging"
+	"lovco/server/metrics"
+	"lovco/server/tracing"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var tracer = otel.Tracer("lovco/server")
+
+type loggedStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	method string
+	enrich bool // add the ids of the first request to ctx
+	recv   int
+	sent   int
+}
+
+// Context carries the logger of the stream, with the ids of the request once it was received.
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *loggedStream) RecvMsg(m any) error {
+	logging.FromContext(s.ctx).Debug("Receive a message", "type", fmt.Sprintf("%T", m), "time", time.Now().Format(time.RFC3339))
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	// the request of a server stream is read before the handler runs, so the handler sees its ids.
+	// Client streams are read by the handler itself, their context must not change under it.
+	if s.enrich && s.recv == 0 {
+		s.ctx = logging.With(s.ctx, requestAttrs(s.method, m)...)
+	}
+	s.recv++
+	return nil
+}
+
+func (s *loggedStream) SendMsg(m any) error {
+	logging.FromContext(s.ctx).Debug("Send a message", "type", fmt.Sprintf("%T", m), "time", time.Now().Format(time.RFC3339))
+	s.sent++
+	return s.ServerStream.SendMsg(m)
+}
+
+// requestID returns the request id the caller sent, or a new one. Ids that are too long or not
+// printable ASCII are replaced since they end up in every log line of the call.
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(logging.RequestIDHeader); len(ids) > 0 && validRequestID(ids[0]) {
+			return ids[0]
+		}
+	}
+	return uuid.NewString()
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// requestAttrs are the user and leftover ids of req, read through the getters of the generated messages.
+// The messages of the leftover service call the leftover id just id.
+func requestAttrs(fullMethod string, req any) []any {
+	var attrs []any
+	if r, ok := req.(interface{ GetUserId() string }); ok && r.GetUserId() != "" {
+		attrs = append(attrs, "user_id", r.GetUserId())
+	}
+	if r, ok := req.(interface{ GetOwnerId() string }); ok && r.GetOwnerId() != "" {
+		attrs = append(attrs, "owner_id", r.GetOwnerId())
+	}
+	if r, ok := req.(interface{ GetLeftoverId() string }); ok && r.GetLeftoverId() != "" {
+		attrs = append(attrs, "leftover_id", r.GetLeftoverId())
+	} else if r, ok := req.(interface{ GetId() string }); ok && r.GetId() != "" && strings.HasPrefix(fullMethod, "/LeftoverService/") {
+		attrs = append(attrs, "leftover_id", r.GetId())
+	}
+	return attrs
+}
+
+// callContext attaches the request id and a logger carrying it to ctx,
+// and echoes the id in the response headers so clients can quote it.
+func callContext(ctx context.Context, logger *slog.Logger, fullMethod string, setHeader func(metadata.MD) error) context.Context {
+	id := requestID(ctx)
+	_ = setHeader(metadata.Pairs(logging.RequestIDHeader, id))
+
+	logger = logger.With("method", fullMethod, "request_id", id)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		logger = logger.With("trace_id", sc.TraceID().String())
+	}
+	return logging.NewContext(logging.WithRequestID(ctx, id), logger)
+}
+
+// loggingUnaryInterceptor logs every call and feeds its outcome to m.
+// The handler gets the logger of the call in its context.
+func loggingUnaryInterceptor(logger *slog.Logger, m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		var peerAddr string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			peerAddr = p.Addr.String()
+		}
+		ctx = callContext(ctx, logger, info.FullMethod, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+		ctx = logging.With(ctx, requestAttrs(info.FullMethod, req)...)
+		log := logging.FromContext(ctx)
+		log.Info("grpc unary start", "peer", peerAddr)
+
+		resp, err := handler(ctx, req)
+		st, _ := status.FromError(err)
+
+		elapsed := time.Since(start)
+		m.ObserveRPC(info.FullMethod, metrics.Unary, st.Code(), elapsed)
+		log.Info("grpc unary end", "code", st.Code().String(), "duration", elapsed, "peer", peerAddr, "err", err)
+		return resp, err
+	}
+}
+
+// loggingStreamInterceptor logs every stream, counts it as active while it is open and feeds its outcome to m.
+// The handler gets the logger of the stream in its context.
+func loggingStreamInterceptor(logger *slog.Logger, m *metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		var peerAddr string
+		if p, ok := peer.FromContext(ss.Context()); ok && p.Addr != nil {
+			peerAddr = p.Addr.String()
+		}
+		wrapped := &loggedStream{
+			ServerStream: ss,
+			ctx:          callContext(ss.Context(), logger, info.FullMethod, ss.SetHeader),
+			method:       info.FullMethod,
+			enrich:       !info.IsClientStream,
+		}
+		logging.FromContext(wrapped.ctx).Info(
+			"grpc stream start",
+			"peer", peerAddr,
+			"client_stream", info.IsClientStream,
+			"server_stream", info.IsServerStream,
+		)
+		m.StreamStarted(info.FullMethod)
+		err := handler(srv, wrapped)
+		m.StreamEnded(info.FullMethod)
+		st, _ := status.FromError(err)
+		elapsed := time.Since(start)
+		m.ObserveRPC(info.FullMethod, metrics.Stream, st.Code(), elapsed)
+		logging.FromContext(wrapped.ctx).Info(
+			"grpc stream end",
+			"code", st.Code().String(),
+			"duration", elapsed,
+			"peer", peerAddr,
+			"recv", wrapped.recv,
+			"sent", wrapped.sent,
+			"err", err,
+		)
+		return err
+	}
+}
+
+// tracedStream hands the context carrying the span of the stream to the handler.
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+// startSpan continues the trace of the caller from the incoming metadata, or starts one,
+// and sends the trace context back in the response headers so clients can look the call up.
+func startSpan(ctx context.Context, fullMethod string, setHeader func(metadata.MD) error) (context.Context, trace.Span) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	ctx, span := tracer.Start(tracing.Extract(ctx), service+"/"+method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", method),
+		),
+	)
+
+	header := metadata.MD{}
+	tracing.Inject(ctx, header)
+	if len(header) > 0 {
+		_ = setHeader(header)
+	}
+	return ctx, span
+}
+
+// endSpan records the status code of the call, server errors mark the span as failed.
+func endSpan(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(st.Code())))
+	switch st.Code() {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+	span.End()
+}
+
+// tracingUnaryInterceptor wraps every call in a server span.
+func tracingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := startSpan(ctx, info.FullMethod, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+		resp, err := handler(ctx, req)
+		endSpan(span, err)
+		return resp, err
+	}
+}
+
+// tracingStreamInterceptor wraps every stream in a server span that lasts until the stream ends.
+func tracingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startSpan(ss.Context(), info.FullMethod, ss.SetHeader)
+		err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+		endSpan(span, err)
+		return err
+	}
+}
@@ -2,216 +2,136 @@ package leftover
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"encoding/json"
+	"errors"
+	"lovco/server/logging"
+	"lovco/server/webhook"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-const (
-	addLeftoverQuery = `
-		INSERT INTO leftover (id, owner_id, name, description, type, image_url, longitude, latitude, street, district, city, province, state, country) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
-	`
-	getLeftoverQuery = `
-		SELECT id, owner_id, name, description, type, image_url, longitude, latitude, street, district, city, province, state, country
-		FROM leftover
-		WHERE id = $1;
-	`
-
-	searchLeftoversQuery = `
-		SELECT id, owner_id, name, description, type, image_url, longitude, latitude, street, district, city, province, state, country
-		FROM leftover
-	`
-
-	updateLeftoverQuery = `
-		UPDATE leftover
-		SET owner_id = $1, name = $2, description = $3, image_url = $4, longitude = $5, latitude = $6, street = $7, district = $8, city = $9, province = $10, state = $11, country = $12
-		WHERE id = $13;
-	`
-	deleteLeftoverQuery = `
-		DELETE FROM leftover
-		WHERE id = $1 AND owner_id = $2;
-	`
-)
-
-type DatabaseInterface interface {
-	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
-	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
-	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
-}
-
-func buildLeftoverSelectQuery(req *LeftoverQuery) (string, []any) {
-	conds := make([]string, 0)
-	args := make([]any, 0)
-	argIdx := 1
-
-	if req.OwnerId != nil {
-		conds = append(conds, fmt.Sprintf("owner_id = $%d", argIdx))
-		args = append(args, req.OwnerId)
-		argIdx++
-	}
-	if req.Name != nil {
-		conds = append(conds, fmt.Sprintf("name ILIKE $%d", argIdx))
-		args = append(args, "%"+*req.Name+"%")
-		argIdx++
-	}
-
-	if req.Type != nil {
-		conds = append(conds, fmt.Sprintf("type = $%d", argIdx))
-		args = append(args, req.Type)
-		argIdx++
-	}
-
-	if req.Bbox != nil {
-		conds = append(conds, fmt.Sprintf("longitude >= $%d AND longitude <= $%d AND latitude >= $%d AND latitude <= $%d", argIdx, argIdx+1, argIdx+2, argIdx+3))
-		args = append(args, req.Bbox.TopLeft.Longitude, req.Bbox.BottomRight.Longitude, req.Bbox.TopLeft.Latitude, req.Bbox.BottomRight.Latitude)
-		argIdx += 4
-	}
-
-	query := searchLeftoversQuery
-	if len(conds) > 0 {
-		query += " WHERE " + strings.Join(conds, " AND ")
-	}
-	return query, args
+// ErrNotFound is returned by a LeftoverStore when the leftover does not exist.
+var ErrNotFound = errors.New("leftover: not found")
+
+// LeftoverStore keeps the leftovers. PostgresLeftoverStore is the production store,
+// storage.MemoryStore keeps everything in memory for development.
+type LeftoverStore interface {
+	// Add also queues a saved_search notification for every other user with a saved search that matches lo.
+	Add(ctx context.Context, lo *Leftover) error
+	Get(ctx context.Context, id string) (*Leftover, error)
+	Search(ctx context.Context, q *LeftoverQuery) ([]*Leftover, error)
+	// Update changes everything but the type and returns the stored type.
+	Update(ctx context.Context, lo *Leftover) (string, error)
+	// Delete removes a leftover of ownerID and returns its id, owner, type and city.
+	Delete(ctx context.Context, id string, ownerID string) (*Leftover, error)
 }
 
 type LeftoverServer struct {
 	UnimplementedLeftoverServiceServer
-	db DatabaseInterface
+	store    LeftoverStore
+	webhooks webhook.Emitter
 }
 
-func NewLeftoverServer(db *pgxpool.Pool) *LeftoverServer {
+func NewLeftoverServer(store LeftoverStore, webhooks webhook.Emitter) *LeftoverServer {
 	return &LeftoverServer{
-		db: db,
+		store:    store,
+		webhooks: webhooks,
 	}
 }
 
-func (s *LeftoverServer) AddLeftover(ctx context.Context, req *LeftoverRequest) (*emptypb.Empty, error) {
-	id := uuid.New()
-
-	_, err := s.db.Exec(ctx, addLeftoverQuery, id, req.OwnerId, req.Name, req.Description, req.Type, req.ImageUrl, req.Coordinates.Longitude, req.Coordinates.Latitude, req.Address.Street, req.Address.District, req.Address.City, req.Address.Province, req.Address.State, req.Address.Country)
+// emit tells the webhooks about a change to a leftover. The change is already stored,
+// so a failure is only logged instead of failing the request.
+func (s *LeftoverServer) emit(ctx context.Context, name string, lo *Leftover) {
+	data, err := protojson.Marshal(lo)
+	if err == nil {
+		err = s.webhooks.Emit(ctx, webhook.Event{
+			Name: name,
+			Type: lo.Type,
+			City: lo.GetAddress().GetCity(),
+			Data: json.RawMessage(data),
+		})
+	}
 	if err != nil {
+		logging.FromContext(ctx).Error("failed to emit webhook event", "event", name, "leftover_id", lo.Id, "error", err)
+	}
+}
+
+func (s *LeftoverServer) AddLeftover(ctx context.Context, req *LeftoverRequest) (*emptypb.Empty, error) {
+	lo := &Leftover{
+		Id:          uuid.NewString(),
+		OwnerId:     req.OwnerId,
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+		ImageUrl:    req.ImageUrl,
+		Coordiantes: req.Coordinates,
+		Address:     req.Address,
+	}
+	if err := s.store.Add(ctx, lo); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to add leftover: %v", err)
 	}
 
+	s.emit(ctx, webhook.EventLeftoverCreated, lo)
+
 	return &emptypb.Empty{}, nil
 }
 
 func (s *LeftoverServer) GetLeftover(ctx context.Context, req *LeftoverIdentity) (*Leftover, error) {
-	row := s.db.QueryRow(ctx, getLeftoverQuery, req.Id)
-	var lo Leftover
-	err := row.Scan(
-		&lo.Id,
-		&lo.OwnerId,
-		&lo.Name,
-		&lo.Description,
-		&lo.Type,
-		&lo.ImageUrl,
-		&lo.Coordiantes.Longitude, &lo.Coordiantes.Latitude,
-		&lo.Address.Street, &lo.Address.District, &lo.Address.City, &lo.Address.Province, &lo.Address.State, &lo.Address.Country)
+	lo, err := s.store.Get(ctx, req.Id)
 	if err != nil {
-		if err.Error() == "no rows in result set" {
+		if errors.Is(err, ErrNotFound) {
 			return nil, status.Errorf(codes.NotFound, "leftover not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get leftover: %v", err)
 	}
 
-	return &Leftover{
-		Id:          lo.Id,
-		OwnerId:     lo.OwnerId,
-		Name:        lo.Name,
-		Description: lo.Description,
-		Type:        lo.Type,
-		ImageUrl:    lo.ImageUrl,
-		Coordiantes: &Point{
-			Longitude: lo.Coordiantes.Longitude,
-			Latitude:  lo.Coordiantes.Latitude,
-		},
-		Address: &Address{
-			Street:   lo.Address.Street,
-			District: lo.Address.District,
-			City:     lo.Address.City,
-			Province: lo.Address.Province,
-			State:    lo.Address.State,
-			Country:  lo.Address.Country,
-		},
-	}, nil
+	return lo, nil
 }
 
 func (s *LeftoverServer) GetLeftovers(ctx context.Context, req *LeftoverQuery) (*LeftoverResponse, error) {
-	items := make([]*Leftover, 0)
-	query, args := buildLeftoverSelectQuery(req)
-
-	rows, err := s.db.Query(ctx, query, args...)
+	items, err := s.store.Search(ctx, req)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to query leftovers: %v", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var lo Leftover
-		err := rows.Scan(&lo.Id, &lo.OwnerId, &lo.Name, &lo.Description, &lo.Type, &lo.ImageUrl, &lo.Coordiantes.Longitude, &lo.Coordiantes.Latitude, &lo.Address.Street, &lo.Address.District, &lo.Address.City, &lo.Address.Province, &lo.Address.State, &lo.Address.Country)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to scan leftover: %v", err)
-		}
-		items = append(items, &Leftover{
-			Id:          lo.Id,
-			OwnerId:     lo.OwnerId,
-			Name:        lo.Name,
-			Description: lo.Description,
-			Type:        lo.Type,
-			ImageUrl:    lo.ImageUrl,
-			Coordiantes: &Point{
-				Longitude: lo.Coordiantes.Longitude,
-				Latitude:  lo.Coordiantes.Latitude,
-			},
-			Address: &Address{
-				Street:   lo.Address.Street,
-				District: lo.Address.District,
-				City:     lo.Address.City,
-				Province: lo.Address.Province,
-				State:    lo.Address.State,
-				Country:  lo.Address.Country,
-			},
-		})
-	}
-	if err := rows.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "error iterating rows: %v", err)
-	}
 
 	return &LeftoverResponse{Items: items}, nil
 }
 
 func (s *LeftoverServer) UpdateLeftover(ctx context.Context, req *Leftover) (*emptypb.Empty, error) {
-	uid, err := uuid.Parse(req.Id)
-	if err != nil {
+	if _, err := uuid.Parse(req.Id); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid UUID format: %v", err)
 	}
-	_, err = s.db.Exec(ctx, updateLeftoverQuery,
-		req.OwnerId,
-		req.Name,
-		req.Description,
-		req.ImageUrl,
-		req.Coordiantes.Longitude, req.Coordiantes.Latitude,
-		req.Address.Street, req.Address.District, req.Address.City, req.Address.Province, req.Address.State, req.Address.Country, uid)
+	// the type cannot be changed, the stored one is needed for the webhook filters
+	leftoverType, err := s.store.Update(ctx, req)
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &emptypb.Empty{}, nil
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update leftover: %v", err)
 	}
 
+	updated := proto.Clone(req).(*Leftover)
+	updated.Type = leftoverType
+	s.emit(ctx, webhook.EventLeftoverUpdated, updated)
+
 	return &emptypb.Empty{}, nil
 }
 
 func (s *LeftoverServer) DeleteLeftover(ctx context.Context, req *DeleteRequest) (*emptypb.Empty, error) {
-	_, err := s.db.Exec(ctx, deleteLeftoverQuery, req.Id, req.OwnerId)
+	deleted, err := s.store.Delete(ctx, req.Id, req.OwnerId)
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &emptypb.Empty{}, nil
+		}
 		return nil, status.Errorf(codes.Internal, "failed to delete leftover: %v", err)
 	}
 
+	s.emit(ctx, webhook.EventLeftoverDeleted, deleted)
+
 	return &emptypb.Empty{}, nil
 }
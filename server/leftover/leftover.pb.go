@@ -676,8 +676,7 @@ const file_leftover_proto_rawDesc = "" +
 	"\vGetLeftover\x12\x11.LeftoverIdentity\x1a\t.Leftover\"\x00\x123\n" +
 	"\fGetLeftovers\x12\x0e.LeftoverQuery\x1a\x11.LeftoverResponse\"\x00\x125\n" +
 	"\x0eUpdateLeftover\x12\t.Leftover\x1a\x16.google.protobuf.Empty\"\x00\x12:\n" +
-	"\x0eDeleteLeftover\x12\x0e.DeleteRequest\x1a\x16.google.protobuf.Empty\"\x00B\fZ\n" +
-	"./leftoverb\x06proto3"
+	"\x0eDeleteLeftover\x12\x0e.DeleteRequest\x1a\x16.google.protobuf.Empty\"\x00B\x17Z\x15lovco/server/leftoverb\x06proto3"
 
 var (
 	file_leftover_proto_rawDescOnce sync.Once
@@ -0,0 +1,198 @@
+package leftover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// the leftover queues a saved_search notification for every other user with a matching
+	// saved search in the same statement, one per user however many of their searches match
+	addLeftoverQuery = `
+		WITH added AS (
+			INSERT INTO leftover (id, owner_id, name, description, type, image_url, longitude, latitude, street, district, city, province, state, country)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			RETURNING id, owner_id, name, type, city
+		)
+		INSERT INTO notification_outbox (user_id, kind, subject_id, payload, created_at, next_attempt_at)
+		SELECT DISTINCT ON (s.user_id) s.user_id, 'saved_search', added.id,
+			jsonb_build_object('leftover_id', added.id, 'search_id', s.id, 'text', 'New leftover ' || added.name || ' in ' || added.city), $15, $15
+		FROM added
+		JOIN saved_search s ON s.user_id <> added.owner_id
+			AND (s.name IS NULL OR strpos(lower(added.name), lower(s.name)) > 0)
+			AND (s.type IS NULL OR s.type = added.type::text)
+			AND (s.city IS NULL OR lower(s.city) = lower(added.city))
+		ORDER BY s.user_id, s.created_at;
+	`
+	getLeftoverQuery = `
+		SELECT id, owner_id, name, description, type, image_url, longitude, latitude, street, district, city, province, state, country
+		FROM leftover
+		WHERE id = $1;
+	`
+
+	searchLeftoversQuery = `
+		SELECT id, owner_id, name, description, type, image_url, longitude, latitude, street, district, city, province, state, country
+		FROM leftover
+	`
+
+	updateLeftoverQuery = `
+		UPDATE leftover
+		SET owner_id = $1, name = $2, description = $3, image_url = $4, longitude = $5, latitude = $6, street = $7, district = $8, city = $9, province = $10, state = $11, country = $12
+		WHERE id = $13
+		RETURNING type;
+	`
+	deleteLeftoverQuery = `
+		DELETE FROM leftover
+		WHERE id = $1 AND owner_id = $2
+		RETURNING type, city;
+	`
+)
+
+type DatabaseInterface interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// PostgresLeftoverStore keeps the leftovers in the leftover table.
+type PostgresLeftoverStore struct {
+	db DatabaseInterface
+}
+
+func NewPostgresLeftoverStore(db DatabaseInterface) *PostgresLeftoverStore {
+	return &PostgresLeftoverStore{
+		db: db,
+	}
+}
+
+func buildLeftoverSelectQuery(req *LeftoverQuery) (string, []any) {
+	conds := make([]string, 0)
+	args := make([]any, 0)
+	argIdx := 1
+
+	if req.OwnerId != nil {
+		conds = append(conds, fmt.Sprintf("owner_id = $%d", argIdx))
+		args = append(args, req.OwnerId)
+		argIdx++
+	}
+	if req.Name != nil {
+		conds = append(conds, fmt.Sprintf("name ILIKE $%d", argIdx))
+		args = append(args, "%"+*req.Name+"%")
+		argIdx++
+	}
+
+	if req.Type != nil {
+		conds = append(conds, fmt.Sprintf("type = $%d", argIdx))
+		args = append(args, req.Type)
+		argIdx++
+	}
+
+	if req.Bbox != nil {
+		conds = append(conds, fmt.Sprintf("longitude >= $%d AND longitude <= $%d AND latitude >= $%d AND latitude <= $%d", argIdx, argIdx+1, argIdx+2, argIdx+3))
+		args = append(args, req.Bbox.TopLeft.Longitude, req.Bbox.BottomRight.Longitude, req.Bbox.TopLeft.Latitude, req.Bbox.BottomRight.Latitude)
+		argIdx += 4
+	}
+
+	query := searchLeftoversQuery
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return query, args
+}
+
+func scanLeftover(row pgx.Row) (*Leftover, error) {
+	lo := &Leftover{
+		Coordiantes: &Point{},
+		Address:     &Address{},
+	}
+	err := row.Scan(
+		&lo.Id,
+		&lo.OwnerId,
+		&lo.Name,
+		&lo.Description,
+		&lo.Type,
+		&lo.ImageUrl,
+		&lo.Coordiantes.Longitude, &lo.Coordiantes.Latitude,
+		&lo.Address.Street, &lo.Address.District, &lo.Address.City, &lo.Address.Province, &lo.Address.State, &lo.Address.Country)
+	if err != nil {
+		return nil, err
+	}
+	return lo, nil
+}
+
+func (s *PostgresLeftoverStore) Add(ctx context.Context, lo *Leftover) error {
+	_, err := s.db.Exec(ctx, addLeftoverQuery, lo.Id, lo.OwnerId, lo.Name, lo.Description, lo.Type, lo.ImageUrl, lo.Coordiantes.Longitude, lo.Coordiantes.Latitude, lo.Address.Street, lo.Address.District, lo.Address.City, lo.Address.Province, lo.Address.State, lo.Address.Country, time.Now().UTC())
+	return err
+}
+
+func (s *PostgresLeftoverStore) Get(ctx context.Context, id string) (*Leftover, error) {
+	lo, err := scanLeftover(s.db.QueryRow(ctx, getLeftoverQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return lo, err
+}
+
+func (s *PostgresLeftoverStore) Search(ctx context.Context, q *LeftoverQuery) ([]*Leftover, error) {
+	query, args := buildLeftoverSelectQuery(q)
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// the span of the query lasts until the rows are closed, this one tells the scan loop apart
+	_, span := otel.Tracer("lovco/server/leftover").Start(ctx, "leftover.scan")
+	defer span.End()
+
+	items := make([]*Leftover, 0)
+	for rows.Next() {
+		lo, err := scanLeftover(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, lo)
+	}
+	span.SetAttributes(attribute.Int("leftover.count", len(items)))
+	return items, rows.Err()
+}
+
+func (s *PostgresLeftoverStore) Update(ctx context.Context, lo *Leftover) (string, error) {
+	var leftoverType string
+	err := s.db.QueryRow(ctx, updateLeftoverQuery,
+		lo.OwnerId,
+		lo.Name,
+		lo.Description,
+		lo.ImageUrl,
+		lo.Coordiantes.Longitude, lo.Coordiantes.Latitude,
+		lo.Address.Street, lo.Address.District, lo.Address.City, lo.Address.Province, lo.Address.State, lo.Address.Country, lo.Id).Scan(&leftoverType)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return leftoverType, err
+}
+
+func (s *PostgresLeftoverStore) Delete(ctx context.Context, id string, ownerID string) (*Leftover, error) {
+	lo := &Leftover{
+		Id:      id,
+		OwnerId: ownerID,
+		Address: &Address{},
+	}
+	err := s.db.QueryRow(ctx, deleteLeftoverQuery, id, ownerID).Scan(&lo.Type, &lo.Address.City)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lo, nil
+}
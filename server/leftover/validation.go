@@ -0,0 +1,78 @@
+package leftover
+
+import (
+	"lovco/server/validate"
+)
+
+// Types are the leftover types, the values of the leftover_type enum.
+var Types = []string{"food", "electronic", "clothing", "other"}
+
+// AddRules declares the rules the requests of the leftover service must follow.
+func AddRules(rules validate.Rules) {
+	rules.Add(&LeftoverRequest{}, append([]validate.Rule{
+		validate.ID("owner_id"),
+		validate.Required("name"),
+		validate.MaxLen("name", 255),
+		validate.Required("type"),
+		validate.OneOf("type", Types...),
+		validate.MaxLen("imageUrl", 255),
+	}, placeRules("coordinates", "address")...)...)
+
+	// the type of a leftover cannot be changed, it is not checked
+	rules.Add(&Leftover{}, append([]validate.Rule{
+		validate.ID("id"),
+		validate.ID("owner_id"),
+		validate.Required("name"),
+		validate.MaxLen("name", 255),
+		validate.MaxLen("imageUrl", 255),
+	}, placeRules("coordiantes", "address")...)...)
+
+	rules.Add(&LeftoverIdentity{}, validate.ID("id"))
+
+	rules.Add(&DeleteRequest{}, validate.ID("id"), validate.ID("owner_id"))
+
+	rules.Add(&LeftoverQuery{},
+		validate.UUID("id"),
+		validate.UUID("owner_id"),
+		validate.MaxLen("name", 255),
+		validate.OneOf("type", Types...),
+		validate.Required("bbox.top_left"),
+		validate.Required("bbox.bottom_right"),
+		validate.Between("bbox.top_left.latitude", -90, 90),
+		validate.Between("bbox.top_left.longitude", -180, 180),
+		validate.Between("bbox.bottom_right.latitude", -90, 90),
+		validate.Between("bbox.bottom_right.longitude", -180, 180),
+		// the stores search from top_left up to bottom_right, swapped corners would find nothing
+		validate.Check(func(q *LeftoverQuery, v *validate.Violations) {
+			topLeft, bottomRight := q.GetBbox().GetTopLeft(), q.GetBbox().GetBottomRight()
+			if topLeft == nil || bottomRight == nil {
+				return
+			}
+			if topLeft.Longitude > bottomRight.Longitude {
+				v.Add("bbox.bottom_right.longitude", validate.ReasonInvalidRange, "must not be less than the longitude of top_left")
+			}
+			if topLeft.Latitude > bottomRight.Latitude {
+				v.Add("bbox.bottom_right.latitude", validate.ReasonInvalidRange, "must not be less than the latitude of top_left")
+			}
+		}),
+	)
+}
+
+// placeRules are the rules of the point and the address of a leftover.
+func placeRules(point string, address string) []validate.Rule {
+	return []validate.Rule{
+		validate.Required(point),
+		validate.Between(point+".latitude", -90, 90),
+		validate.Between(point+".longitude", -180, 180),
+		validate.Required(address),
+		validate.Required(address + ".street"),
+		validate.Required(address + ".city"),
+		validate.Required(address + ".country"),
+		validate.MaxLen(address+".street", 255),
+		validate.MaxLen(address+".district", 255),
+		validate.MaxLen(address+".city", 255),
+		validate.MaxLen(address+".province", 255),
+		validate.MaxLen(address+".state", 255),
+		validate.MaxLen(address+".country", 255),
+	}
+}
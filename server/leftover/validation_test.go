@@ -0,0 +1,85 @@
+package leftover
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"lovco/server/validate"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fields returns the fields Check reports for req, nil when it passes.
+func fields(t *testing.T, rules validate.Rules, req proto.Message) []string {
+	t.Helper()
+	var got []string
+	for _, detail := range status.Convert(rules.Check(req)).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, fv := range br.FieldViolations {
+				got = append(got, fv.Field)
+			}
+		}
+	}
+	return got
+}
+
+func TestRules(t *testing.T) {
+	rules := validate.Rules{}
+	AddRules(rules)
+
+	const id = "0b8f4c4e-2d44-4b8e-9a43-5d2c1f1a0e6b"
+	request := func(change func(r *LeftoverRequest)) *LeftoverRequest {
+		r := &LeftoverRequest{
+			OwnerId:     id,
+			Name:        "Bread",
+			Type:        "food",
+			Coordinates: &Point{Latitude: 48.2, Longitude: 16.37},
+			Address:     &Address{Street: "Ring 1", City: "Vienna", Country: "Austria"},
+		}
+		if change != nil {
+			change(r)
+		}
+		return r
+	}
+	query := func(top, left, bottom, right float64) *LeftoverQuery {
+		return &LeftoverQuery{Bbox: &BoundingBox{
+			TopLeft:     &Point{Latitude: top, Longitude: left},
+			BottomRight: &Point{Latitude: bottom, Longitude: right},
+		}}
+	}
+	text := func(s string) *string { return &s }
+
+	tests := []struct {
+		name string
+		req  proto.Message
+		want []string
+	}{
+		{"valid request", request(nil), nil},
+		{"no owner", request(func(r *LeftoverRequest) { r.OwnerId = "" }), []string{"owner_id"}},
+		{"owner not a uuid", request(func(r *LeftoverRequest) { r.OwnerId = "42" }), []string{"owner_id"}},
+		{"unknown type", request(func(r *LeftoverRequest) { r.Type = "cars" }), []string{"type"}},
+		{"long name", request(func(r *LeftoverRequest) { r.Name = strings.Repeat("a", 256) }), []string{"name"}},
+		{"latitude out of range", request(func(r *LeftoverRequest) { r.Coordinates.Latitude = 91 }), []string{"coordinates.latitude"}},
+		{"no address", request(func(r *LeftoverRequest) { r.Address = nil }), []string{"address"}},
+		{"no city", request(func(r *LeftoverRequest) { r.Address.City = "" }), []string{"address.city"}},
+
+		{"valid query", query(10, 10, 20, 20), nil},
+		{"swapped longitudes", query(10, 20, 20, 10), []string{"bbox.bottom_right.longitude"}},
+		{"swapped latitudes", query(20, 10, 10, 20), []string{"bbox.bottom_right.latitude"}},
+		{"no corners", &LeftoverQuery{Bbox: &BoundingBox{}}, []string{"bbox.top_left", "bbox.bottom_right"}},
+		{"query owner not a uuid", &LeftoverQuery{OwnerId: text("42")}, []string{"owner_id"}},
+		{"query type", &LeftoverQuery{Type: text("cars")}, []string{"type"}},
+
+		{"delete without ids", &DeleteRequest{}, []string{"id", "owner_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(t, rules, tt.req); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
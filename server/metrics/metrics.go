@@ -0,0 +1,219 @@
+// Package metrics collects the Prometheus metrics of the server and serves them over HTTP.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"lovco/server/chat"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+const namespace = "lovco"
+
+// RPC types used as the type label.
+const (
+	Unary  = "unary"
+	Stream = "stream"
+)
+
+// Metrics holds the collectors the interceptors and the services feed, on a registry of its own.
+type Metrics struct {
+	registry      *prometheus.Registry
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	activeStreams *prometheus.GaugeVec
+	panics        *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Finished gRPC calls by method, type and status code.",
+		}, []string{"method", "type", "code"}),
+		// chat and queue streams stay open for as long as the user is in the room,
+		// so the buckets go up to an hour
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of finished gRPC calls by method and type.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300, 900, 3600},
+		}, []string{"method", "type"}),
+		activeStreams: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "active_streams",
+			Help:      "Open gRPC streams by method.",
+		}, []string{"method"}),
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "panics_total",
+			Help:      "Handler panics turned into Internal errors, by method.",
+		}, []string{"method"}),
+	}
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.activeStreams,
+		m.panics,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// ObserveRPC records a finished call.
+func (m *Metrics) ObserveRPC(method string, rpcType string, code codes.Code, d time.Duration) {
+	m.requests.WithLabelValues(method, rpcType, code.String()).Inc()
+	m.duration.WithLabelValues(method, rpcType).Observe(d.Seconds())
+}
+
+// StreamStarted counts an open stream until StreamEnded is called for it.
+func (m *Metrics) StreamStarted(method string) {
+	m.activeStreams.WithLabelValues(method).Inc()
+}
+
+func (m *Metrics) StreamEnded(method string) {
+	m.activeStreams.WithLabelValues(method).Dec()
+}
+
+// Panicked counts a handler panic of method.
+func (m *Metrics) Panicked(method string) {
+	m.panics.WithLabelValues(method).Inc()
+}
+
+// MustRegister adds collectors, e.g. those of NewChatCollector and NewPoolCollector,
+// and panics when one of them clashes with a registered one.
+func (m *Metrics) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Serve serves /metrics on lis until ctx is done.
+func (m *Metrics) Serve(ctx context.Context, lis net.Listener, logger *slog.Logger) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("failed to stop the metrics server", "error", err)
+		}
+	}()
+
+	logger.Info("Serving metrics", "address", lis.Addr().String())
+	if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// chatCollector reads the chat stats on every scrape.
+type chatCollector struct {
+	stats        func() chat.Stats
+	rooms        *prometheus.Desc
+	queued       *prometheus.Desc
+	sendFailures *prometheus.Desc
+}
+
+// NewChatCollector reports the rooms, queues and broadcast failures of a chat server.
+func NewChatCollector(stats func() chat.Stats) prometheus.Collector {
+	return &chatCollector{
+		stats: stats,
+		rooms: prometheus.NewDesc(prometheus.BuildFQName(namespace, "chat", "rooms"),
+			"Chat rooms somebody is seated or queued in.", nil, nil),
+		queued: prometheus.NewDesc(prometheus.BuildFQName(namespace, "chat", "queued_users"),
+			"Users waiting in the queues of all chat rooms.", nil, nil),
+		sendFailures: prometheus.NewDesc(prometheus.BuildFQName(namespace, "chat", "broadcast_send_failures_total"),
+			"Chat broadcasts that could not be sent on a stream of this replica.", nil, nil),
+	}
+}
+
+func (c *chatCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rooms
+	ch <- c.queued
+	ch <- c.sendFailures
+}
+
+func (c *chatCollector) Collect(ch chan<- prometheus.Metric) {
+	st := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.rooms, prometheus.GaugeValue, float64(st.Rooms))
+	ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(st.Queued))
+	ch <- prometheus.MustNewConstMetric(c.sendFailures, prometheus.CounterValue, float64(st.SendFailures))
+}
+
+// poolCollector reads the pgxpool stats on every scrape.
+type poolCollector struct {
+	pool  *pgxpool.Pool
+	descs map[string]*prometheus.Desc
+}
+
+// poolMetrics are the pool stats, gauges first, then counters.
+var poolMetrics = []struct {
+	name      string
+	help      string
+	valueType prometheus.ValueType
+	value     func(st *pgxpool.Stat) float64
+}{
+	{"acquired_conns", "Connections currently in use.", prometheus.GaugeValue, func(st *pgxpool.Stat) float64 { return float64(st.AcquiredConns()) }},
+	{"idle_conns", "Idle connections in the pool.", prometheus.GaugeValue, func(st *pgxpool.Stat) float64 { return float64(st.IdleConns()) }},
+	{"constructing_conns", "Connections being opened.", prometheus.GaugeValue, func(st *pgxpool.Stat) float64 { return float64(st.ConstructingConns()) }},
+	{"total_conns", "Connections in the pool, in use, idle or being opened.", prometheus.GaugeValue, func(st *pgxpool.Stat) float64 { return float64(st.TotalConns()) }},
+	{"max_conns", "Maximum size of the pool.", prometheus.GaugeValue, func(st *pgxpool.Stat) float64 { return float64(st.MaxConns()) }},
+	{"acquires_total", "Successful connection acquires.", prometheus.CounterValue, func(st *pgxpool.Stat) float64 { return float64(st.AcquireCount()) }},
+	{"acquire_duration_seconds_total", "Time spent acquiring connections.", prometheus.CounterValue, func(st *pgxpool.Stat) float64 { return st.AcquireDuration().Seconds() }},
+	{"canceled_acquires_total", "Acquires canceled by their context.", prometheus.CounterValue, func(st *pgxpool.Stat) float64 { return float64(st.CanceledAcquireCount()) }},
+	{"empty_acquires_total", "Acquires that had to wait for a connection.", prometheus.CounterValue, func(st *pgxpool.Stat) float64 { return float64(st.EmptyAcquireCount()) }},
+	{"new_conns_total", "Connections opened.", prometheus.CounterValue, func(st *pgxpool.Stat) float64 { return float64(st.NewConnsCount()) }},
+	{"max_lifetime_destroys_total", "Connections closed for reaching the maximum lifetime.", prometheus.CounterValue, func(st *pgxpool.Stat) float64 { return float64(st.MaxLifetimeDestroyCount()) }},
+	{"max_idle_destroys_total", "Connections closed for being idle too long.", prometheus.CounterValue, func(st *pgxpool.Stat) float64 { return float64(st.MaxIdleDestroyCount()) }},
+}
+
+// NewPoolCollector reports the connection stats of pool.
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	c := &poolCollector{
+		pool:  pool,
+		descs: make(map[string]*prometheus.Desc, len(poolMetrics)),
+	}
+	for _, pm := range poolMetrics {
+		c.descs[pm.name] = prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", pm.name), pm.help, nil, nil)
+	}
+	return c
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	st := c.pool.Stat()
+	for _, pm := range poolMetrics {
+		ch <- prometheus.MustNewConstMetric(c.descs[pm.name], pm.valueType, pm.value(st))
+	}
+}
@@ -0,0 +1,319 @@
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrations are named <version>_<name>.up.sql and <version>_<name>.down.sql,
+// versions are applied in increasing order. A migration without a down file cannot
+// be reverted, like the baseline that holds the leftovers of existing databases.
+//
+//go:embed migrations/*.sql
+var files embed.FS
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// ErrModified is returned when an applied migration was edited afterwards.
+var ErrModified = errors.New("migrate: applied migration was modified")
+
+// lockKey is the advisory lock held while migrating, so replicas starting together take turns.
+const lockKey = 0x6c6f76636f
+
+const (
+	createTableQuery = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	appliedQuery = `
+		SELECT version, checksum, applied_at
+		FROM schema_migrations
+		ORDER BY version;
+	`
+	insertQuery = `
+		INSERT INTO schema_migrations (version, name, checksum, applied_at)
+		VALUES ($1, $2, $3, $4);
+	`
+	deleteQuery = `
+		DELETE FROM schema_migrations
+		WHERE version = $1;
+	`
+)
+
+// Migration is a schema change and the way to undo it.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // of Up, an applied migration must not change
+}
+
+// Status is a migration and whether it is applied to the database.
+// Modified is set when the applied migration differs from the embedded one.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	Modified  bool
+}
+
+// applied is a row of schema_migrations.
+type applied struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// Load returns the embedded migrations ordered by version.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrate: unexpected file name %q", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: bad version in %q: %w", entry.Name(), err)
+		}
+		data, err := files.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migrate: version %d is used by %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+			sum := sha256.Sum256(data)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migrate: version %d has no up migration", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return migrations, nil
+}
+
+// Migrator applies the embedded migrations to a database.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+func New(pool *pgxpool.Pool, logger *slog.Logger) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// Latest is the version of the newest embedded migration, 0 if there is none.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every migration that is not applied yet.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the newest applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(ctx, true, func(conn *pgx.Conn, done map[int64]applied) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := done[m.migrations[i].Version]; ok {
+				return m.revert(ctx, conn, m.migrations[i])
+			}
+		}
+		m.logger.Info("no migration to revert")
+		return nil
+	})
+}
+
+// To applies or reverts migrations until version is the newest applied one.
+// Version 0 reverts everything, which the baseline migration refuses.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && !slices.ContainsFunc(m.migrations, func(mg Migration) bool { return mg.Version == version }) {
+		return fmt.Errorf("migrate: unknown version %d", version)
+	}
+
+	return m.locked(ctx, true, func(conn *pgx.Conn, done map[int64]applied) error {
+		// refuse before reverting anything rather than stopping halfway
+		for _, mg := range m.migrations {
+			if _, ok := done[mg.Version]; ok && mg.Version > version && mg.Down == "" {
+				return irreversible(mg)
+			}
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mg := m.migrations[i]
+			if _, ok := done[mg.Version]; ok && mg.Version > version {
+				if err := m.revert(ctx, conn, mg); err != nil {
+					return err
+				}
+			}
+		}
+		for _, mg := range m.migrations {
+			if _, ok := done[mg.Version]; !ok && mg.Version <= version {
+				if err := m.apply(ctx, conn, mg); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists the embedded migrations and whether they are applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, false, func(conn *pgx.Conn, done map[int64]applied) error {
+		statuses = make([]Status, 0, len(m.migrations))
+		for _, mg := range m.migrations {
+			st := Status{Migration: mg}
+			if a, ok := done[mg.Version]; ok {
+				st.Applied = true
+				st.AppliedAt = a.appliedAt
+				st.Modified = a.checksum != mg.Checksum
+			}
+			statuses = append(statuses, st)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// locked runs fn on a connection holding the migration lock, with the applied migrations.
+// With verify set it refuses to run when an applied migration was changed since.
+func (m *Migrator) locked(ctx context.Context, verify bool, fn func(conn *pgx.Conn, done map[int64]applied) error) error {
+	pooled, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer pooled.Release()
+	conn := pooled.Conn()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", int64(lockKey)); err != nil {
+		return fmt.Errorf("migrate: failed to take lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", int64(lockKey)); err != nil {
+			m.logger.Error("failed to release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, createTableQuery); err != nil {
+		return fmt.Errorf("migrate: failed to create schema_migrations: %w", err)
+	}
+
+	done, err := readApplied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if verify {
+		for _, mg := range m.migrations {
+			if a, ok := done[mg.Version]; ok && a.checksum != mg.Checksum {
+				return fmt.Errorf("%w: %d_%s, add a new migration instead", ErrModified, mg.Version, mg.Name)
+			}
+		}
+	}
+	return fn(conn, done)
+}
+
+func readApplied(ctx context.Context, conn *pgx.Conn) (map[int64]applied, error) {
+	rows, err := conn.Query(ctx, appliedQuery)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int64]applied)
+	for rows.Next() {
+		var (
+			version int64
+			a       applied
+		)
+		if err := rows.Scan(&version, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = a
+	}
+	return done, rows.Err()
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *pgx.Conn, mg Migration) error {
+	m.logger.Info("applying migration", "version", mg.Version, "name", mg.Name)
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, mg.Up); err != nil {
+			return fmt.Errorf("migrate: %d_%s up: %w", mg.Version, mg.Name, err)
+		}
+		_, err := tx.Exec(ctx, insertQuery, mg.Version, mg.Name, mg.Checksum, time.Now().UTC())
+		return err
+	})
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *pgx.Conn, mg Migration) error {
+	if mg.Down == "" {
+		return irreversible(mg)
+	}
+
+	m.logger.Info("reverting migration", "version", mg.Version, "name", mg.Name)
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, mg.Down); err != nil {
+			return fmt.Errorf("migrate: %d_%s down: %w", mg.Version, mg.Name, err)
+		}
+		_, err := tx.Exec(ctx, deleteQuery, mg.Version)
+		return err
+	})
+}
+
+func irreversible(mg Migration) error {
+	return fmt.Errorf("migrate: %d_%s cannot be reverted, it has no down migration", mg.Version, mg.Name)
+}
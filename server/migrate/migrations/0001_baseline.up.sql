@@ -1,4 +1,9 @@
-CREATE TYPE leftover_type AS ENUM ('food', 'electronic', 'clothing', 'other');
+-- the schema of the former docker entrypoint script, databases created by it already have it
+DO $$ BEGIN
+	CREATE TYPE leftover_type AS ENUM ('food', 'electronic', 'clothing', 'other');
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END $$;
 
 CREATE TABLE IF NOT EXISTS leftover (
 	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -18,4 +23,4 @@ CREATE TABLE IF NOT EXISTS leftover (
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	deleted_at TIMESTAMP NULL
-);
\ No newline at end of file
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS chat_message (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	leftover_id UUID NOT NULL REFERENCES leftover(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL,
+	message TEXT NOT NULL,
+	image VARCHAR(255) NOT NULL DEFAULT '',
+	edited BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS chat_message_leftover_idx ON chat_message (leftover_id, created_at);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS chat_room (
+	leftover_id UUID PRIMARY KEY REFERENCES leftover(id) ON DELETE CASCADE,
+	owner_id UUID NULL,
+	guest_id UUID NULL,
+	queue UUID[] NOT NULL DEFAULT '{}',
+	saved_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS chat_ban;
+DROP TABLE IF EXISTS user_block;
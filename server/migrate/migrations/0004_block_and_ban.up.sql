@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS user_block (
+	user_id UUID NOT NULL,
+	blocked_user_id UUID NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (user_id, blocked_user_id)
+);
+
+CREATE TABLE IF NOT EXISTS chat_ban (
+	leftover_id UUID NOT NULL REFERENCES leftover(id) ON DELETE CASCADE,
+	user_id UUID NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (leftover_id, user_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pickup_agreement;
+ALTER TABLE chat_message DROP COLUMN IF EXISTS payload;
@@ -0,0 +1,11 @@
+ALTER TABLE chat_message ADD COLUMN IF NOT EXISTS payload JSONB NULL;
+
+CREATE TABLE IF NOT EXISTS pickup_agreement (
+	leftover_id UUID PRIMARY KEY REFERENCES leftover(id) ON DELETE CASCADE,
+	message_id UUID NOT NULL REFERENCES chat_message(id) ON DELETE CASCADE,
+	proposer_id UUID NOT NULL,
+	accepted_by UUID NOT NULL,
+	starts_at TIMESTAMP NOT NULL,
+	ends_at TIMESTAMP NOT NULL,
+	accepted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_preference;
+DROP TABLE IF EXISTS notification_outbox;
+ALTER TABLE chat_message DROP COLUMN IF EXISTS delivered_at;
@@ -0,0 +1,25 @@
+ALTER TABLE chat_message ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP NULL;
+
+CREATE TABLE IF NOT EXISTS notification_outbox (
+	id BIGSERIAL PRIMARY KEY,
+	user_id UUID NOT NULL,
+	kind VARCHAR(32) NOT NULL,
+	subject_id UUID NULL,
+	payload JSONB NOT NULL DEFAULT '{}',
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	sent_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS notification_outbox_pending_idx ON notification_outbox (next_attempt_at) WHERE sent_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notification_preference (
+	user_id UUID NOT NULL,
+	kind VARCHAR(32) NOT NULL,
+	channel VARCHAR(16) NOT NULL,
+	target VARCHAR(255) NOT NULL DEFAULT '',
+	enabled BOOLEAN NOT NULL DEFAULT TRUE,
+	PRIMARY KEY (user_id, kind)
+);
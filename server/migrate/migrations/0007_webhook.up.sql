@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS webhook (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	owner_id UUID NOT NULL,
+	url VARCHAR(2048) NOT NULL,
+	secret VARCHAR(255) NOT NULL,
+	event_types TEXT[] NOT NULL,
+	filter_type VARCHAR(32) NULL,
+	filter_city VARCHAR(255) NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+	id BIGSERIAL PRIMARY KEY,
+	webhook_id UUID NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+	event_type VARCHAR(64) NOT NULL,
+	payload JSONB NOT NULL,
+	status VARCHAR(16) NOT NULL DEFAULT 'pending',
+	attempts INT NOT NULL DEFAULT 0,
+	last_status_code INT NULL,
+	last_error TEXT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	delivered_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_idx ON webhook_delivery (webhook_id, created_at);
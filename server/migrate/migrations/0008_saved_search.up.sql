@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS saved_search (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	user_id UUID NOT NULL,
+	name VARCHAR(255) NULL,
+	type VARCHAR(32) NULL,
+	city VARCHAR(255) NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS saved_search_user_idx ON saved_search (user_id, created_at);
@@ -0,0 +1,202 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// DispatcherOptions tunes the dispatcher.
+// Interval is how often the outbox is polled, BatchWindow how long items wait to be batched,
+// BatchSize how many rows one poll claims, MaxAttempts when a failing notification is given up
+// and Timeout how long sending a single notification may take, 0 for no limit.
+// DefaultChannel is used for users without a preference for a kind.
+type DispatcherOptions struct {
+	Interval       time.Duration
+	BatchWindow    time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	Timeout        time.Duration
+	DefaultChannel string
+}
+
+// Dispatcher sends the notifications waiting in the outbox through the channel each user chose.
+// Several replicas can run it at the same time, a claim leases the rows to one of them for as long
+// as sending the whole batch may take. Nothing is held open while sending, each outcome is recorded
+// on its own once the notification was sent or failed.
+type Dispatcher struct {
+	store   NotificationStore
+	senders map[string]Sender
+	opts    DispatcherOptions
+	logger  *slog.Logger
+}
+
+func NewDispatcher(store NotificationStore, senders map[string]Sender, opts DispatcherOptions, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		store:   store,
+		senders: senders,
+		opts:    opts,
+		logger:  logger,
+	}
+}
+
+// Run polls the outbox until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.dispatch(ctx); err != nil && ctx.Err() == nil {
+				d.logger.Error("failed to dispatch notifications", "error", err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) error {
+	now := time.Now().UTC()
+	// at worst every item is sent on its own, the lease covers a batch of timeouts
+	lease := time.Duration(d.opts.BatchSize)*d.opts.Timeout + d.opts.Interval
+	items, err := d.store.ClaimOutbox(ctx, now.Add(-d.opts.BatchWindow), now, now.Add(lease), d.opts.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	// items are ordered by user and kind, every run of the same pair becomes one notification
+	for start := 0; start < len(items); {
+		end := start + 1
+		for end < len(items) && items[end].UserID == items[start].UserID && items[end].Kind == items[start].Kind {
+			end++
+		}
+		if err := d.send(ctx, items[start:end], now); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			// the items are due again when their lease ends
+			d.logger.Error("failed to record notification", "user_id", items[start].UserID, "kind", items[start].Kind, "error", err)
+		}
+		start = end
+	}
+	return nil
+}
+
+// send delivers one batch and records the outcome. Only database errors are returned,
+// a failing sender is retried on a later run.
+func (d *Dispatcher) send(ctx context.Context, batch []OutboxItem, now time.Time) error {
+	userID, kind := batch[0].UserID, batch[0].Kind
+
+	fresh := make([]OutboxItem, 0, len(batch))
+	skipped := make([]int64, 0)
+	for _, it := range batch {
+		if it.Stale {
+			skipped = append(skipped, it.ID)
+		} else {
+			fresh = append(fresh, it)
+		}
+	}
+
+	pref, err := d.store.Preference(ctx, userID, kind)
+	if err != nil {
+		return err
+	}
+	if pref == nil {
+		pref = &Preference{Kind: kind, Channel: d.opts.DefaultChannel, Enabled: true}
+	}
+	if !pref.Enabled {
+		skipped = append(skipped, ids(fresh)...)
+		fresh = nil
+	}
+	if len(skipped) > 0 {
+		if err := d.store.MarkSkipped(ctx, skipped, now); err != nil {
+			return err
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	sender, ok := d.senders[pref.Channel]
+	if !ok {
+		return d.fail(ctx, fresh, fmt.Errorf("no sender for channel %q", pref.Channel), now)
+	}
+
+	n := render(userID, kind, pref.Target, fresh)
+	if err := d.sendWithin(ctx, sender, n); err != nil {
+		return d.fail(ctx, fresh, err, now)
+	}
+
+	d.logger.Info("notification sent", "user_id", userID, "kind", kind, "channel", pref.Channel, "items", len(fresh))
+	return d.store.MarkSent(ctx, ids(fresh), now, "")
+}
+
+// sendWithin sends n, giving up after the timeout of the options unless it is 0.
+func (d *Dispatcher) sendWithin(ctx context.Context, sender Sender, n Notification) error {
+	if d.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.opts.Timeout)
+		defer cancel()
+	}
+	return sender.Send(ctx, n)
+}
+
+// fail schedules a retry with exponential backoff, or gives up after MaxAttempts.
+func (d *Dispatcher) fail(ctx context.Context, batch []OutboxItem, sendErr error, now time.Time) error {
+	attempts := batch[0].Attempts + 1
+	d.logger.Error("failed to send notification", "user_id", batch[0].UserID, "kind", batch[0].Kind, "attempts", attempts, "error", sendErr)
+
+	if attempts >= d.opts.MaxAttempts {
+		return d.store.MarkSent(ctx, ids(batch), now, "gave up: "+sendErr.Error())
+	}
+
+	backoff := d.opts.Interval << min(attempts, 10)
+	return d.store.MarkFailed(ctx, ids(batch), sendErr.Error(), now.Add(backoff))
+}
+
+func ids(items []OutboxItem) []int64 {
+	out := make([]int64, len(items))
+	for i, it := range items {
+		out[i] = it.ID
+	}
+	return out
+}
+
+// render turns a batch into a single notification with a short summary per item.
+func render(userID string, kind string, target string, batch []OutboxItem) Notification {
+	n := Notification{
+		UserID: userID,
+		Kind:   kind,
+		Target: target,
+		Items:  make([]json.RawMessage, len(batch)),
+	}
+
+	var body strings.Builder
+	for i, it := range batch {
+		n.Items[i] = it.Payload
+		var summary struct {
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(it.Payload, &summary) == nil && summary.Text != "" {
+			fmt.Fprintf(&body, "- %s\n", summary.Text)
+		}
+	}
+	n.Body = body.String()
+
+	switch kind {
+	case KindChatMessage:
+		n.Subject = fmt.Sprintf("You have %d unread chat messages", len(batch))
+	case KindClaimDecision:
+		n.Subject = fmt.Sprintf("%d answers to your pickup proposals", len(batch))
+	case KindSavedSearch:
+		n.Subject = fmt.Sprintf("%d new leftovers match your saved searches", len(batch))
+	default:
+		n.Subject = fmt.Sprintf("%d new notifications", len(batch))
+	}
+	return n
+}
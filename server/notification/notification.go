@@ -0,0 +1,167 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Kinds of notifications a user can get.
+const (
+	KindChatMessage   = "chat_message"
+	KindClaimDecision = "claim_decision"
+	KindSavedSearch   = "saved_search"
+)
+
+// Channels a notification can be sent on.
+const (
+	ChannelLog     = "log"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+var (
+	kinds    = []string{KindChatMessage, KindClaimDecision, KindSavedSearch}
+	channels = []string{ChannelLog, ChannelEmail, ChannelWebhook}
+)
+
+// MaxSavedSearches is how many saved searches a user can have.
+const MaxSavedSearches = 20
+
+// OutboxItem is a claimed outbox item. Stale items are not sent: chat items that were delivered
+// on a live stream or deleted while they waited, and saved search hits whose leftover is gone.
+type OutboxItem struct {
+	ID       int64
+	UserID   string
+	Kind     string
+	Payload  json.RawMessage
+	Attempts int
+	Stale    bool
+}
+
+// OutboxRecorder records the outcome of claimed outbox items. Items that were sent in the meantime,
+// e.g. by another dispatcher after the lease ran out, are left alone.
+type OutboxRecorder interface {
+	MarkSkipped(ctx context.Context, ids []int64, at time.Time) error
+	// MarkSent counts a last attempt, note says why when it was given up instead.
+	MarkSent(ctx context.Context, ids []int64, at time.Time, note string) error
+	MarkFailed(ctx context.Context, ids []int64, lastError string, next time.Time) error
+}
+
+// NotificationStore keeps the preferences and the outbox. PostgresNotificationStore is the
+// production store, storage.MemoryStore keeps everything in memory for development.
+type NotificationStore interface {
+	OutboxRecorder
+	Preferences(ctx context.Context, userID string) ([]*Preference, error)
+	// Preference returns nil when the user has no preference for kind.
+	Preference(ctx context.Context, userID string, kind string) (*Preference, error)
+	UpsertPreference(ctx context.Context, userID string, p *Preference) error
+	// SaveSearch stores s unless its user already has limit saved searches, which it reports with false.
+	// Leftovers added later by other users queue a saved_search notification for every user with a matching search.
+	SaveSearch(ctx context.Context, s *SavedSearch, limit int) (bool, error)
+	SavedSearches(ctx context.Context, userID string) ([]*SavedSearch, error)
+	// DeleteSavedSearch reports false when userID has no saved search with that id.
+	DeleteSavedSearch(ctx context.Context, id string, userID string) (bool, error)
+	// Enqueue adds an item to the outbox, subjectID may be empty.
+	Enqueue(ctx context.Context, userID string, kind string, subjectID string, payload json.RawMessage, at time.Time) error
+	// ClaimOutbox leases up to limit unsent items created before createdBefore and due at now until leaseUntil
+	// and returns them ordered by user, kind and creation. Other dispatchers skip them meanwhile,
+	// those whose outcome is not recorded by then are due again.
+	ClaimOutbox(ctx context.Context, createdBefore time.Time, now time.Time, leaseUntil time.Time, limit int) ([]OutboxItem, error)
+}
+
+// Notification is what a Sender delivers: one or more outbox items of the same kind for one user.
+type Notification struct {
+	UserID  string            `json:"user_id"`
+	Kind    string            `json:"kind"`
+	Target  string            `json:"-"`
+	Subject string            `json:"subject"`
+	Body    string            `json:"body"`
+	Items   []json.RawMessage `json:"items"`
+}
+
+// Enqueue adds a notification for userID to the outbox, the dispatcher sends it later.
+func Enqueue(ctx context.Context, store NotificationStore, userID string, kind string, subjectID string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return store.Enqueue(ctx, userID, kind, subjectID, data, time.Now().UTC())
+}
+
+type NotificationServer struct {
+	UnimplementedNotificationServiceServer
+	store NotificationStore
+}
+
+func NewNotificationServer(store NotificationStore) *NotificationServer {
+	return &NotificationServer{
+		store: store,
+	}
+}
+
+func (s *NotificationServer) GetPreferences(ctx context.Context, req *PreferencesRequest) (*Preferences, error) {
+	items, err := s.store.Preferences(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query preferences: %v", err)
+	}
+
+	return &Preferences{UserId: req.UserId, Items: items}, nil
+}
+
+func (s *NotificationServer) UpdatePreferences(ctx context.Context, req *Preferences) (*emptypb.Empty, error) {
+	for _, p := range req.Items {
+		if err := s.store.UpsertPreference(ctx, req.UserId, p); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update preference: %v", err)
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *NotificationServer) SaveSearch(ctx context.Context, req *SavedSearch) (*SavedSearch, error) {
+	search := &SavedSearch{
+		Id:        uuid.NewString(),
+		UserId:    req.UserId,
+		Name:      req.Name,
+		Type:      req.Type,
+		City:      req.City,
+		CreatedAt: timestamppb.Now(),
+	}
+	saved, err := s.store.SaveSearch(ctx, search, MaxSavedSearches)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save search: %v", err)
+	}
+	if !saved {
+		return nil, status.Errorf(codes.ResourceExhausted, "a user can have at most %d saved searches", MaxSavedSearches)
+	}
+
+	return search, nil
+}
+
+func (s *NotificationServer) ListSavedSearches(ctx context.Context, req *SavedSearchesRequest) (*SavedSearches, error) {
+	items, err := s.store.SavedSearches(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query saved searches: %v", err)
+	}
+
+	return &SavedSearches{Items: items}, nil
+}
+
+func (s *NotificationServer) DeleteSavedSearch(ctx context.Context, req *SavedSearchIdentity) (*emptypb.Empty, error) {
+	deleted, err := s.store.DeleteSavedSearch(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete saved search: %v", err)
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "saved search not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,240 @@
+package notification
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// EnqueueQuery adds a row to the outbox. Producers that need the notification to be
+	// written together with their own change embed it in the same statement or transaction.
+	EnqueueQuery = `
+		INSERT INTO notification_outbox (user_id, kind, subject_id, payload, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $5);
+	`
+	getPreferencesQuery = `
+		SELECT kind, channel, target, enabled
+		FROM notification_preference
+		WHERE user_id = $1
+		ORDER BY kind;
+	`
+	upsertPreferenceQuery = `
+		INSERT INTO notification_preference (user_id, kind, channel, target, enabled)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, kind) DO UPDATE
+		SET channel = EXCLUDED.channel, target = EXCLUDED.target, enabled = EXCLUDED.enabled;
+	`
+	// the limit is checked in the same statement, concurrent saves of one user may still exceed it
+	saveSearchQuery = `
+		INSERT INTO saved_search (id, user_id, name, type, city, created_at)
+		SELECT $1::uuid, $2::uuid, $3::text, $4::text, $5::text, $6::timestamp
+		WHERE (SELECT count(*) FROM saved_search WHERE user_id = $2) < $7;
+	`
+	listSavedSearchesQuery = `
+		SELECT id, user_id, name, type, city, created_at
+		FROM saved_search
+		WHERE user_id = $1
+		ORDER BY created_at;
+	`
+	deleteSavedSearchQuery = `
+		DELETE FROM saved_search
+		WHERE id = $1 AND user_id = $2;
+	`
+	// rows are only picked up once they waited out the batch window, so a burst of chat
+	// messages ends up in a single notification. Chat items the recipient already got on
+	// a live stream or saw deleted and saved search hits on deleted leftovers are skipped. The rows are leased by moving their
+	// next attempt to the end of the lease, they are only locked for the statement.
+	claimOutboxQuery = `
+		WITH due AS (
+			SELECT o.id, COALESCE(m.delivered_at IS NOT NULL OR m.deleted_at IS NOT NULL, FALSE)
+				OR (o.kind = 'saved_search' AND l.id IS NULL) AS stale
+			FROM notification_outbox o
+			LEFT JOIN chat_message m ON o.kind = 'chat_message' AND m.id = o.subject_id
+			LEFT JOIN leftover l ON o.kind = 'saved_search' AND l.id = o.subject_id
+			WHERE o.sent_at IS NULL AND o.created_at <= $1 AND o.next_attempt_at <= $2
+			ORDER BY o.user_id, o.kind, o.created_at
+			LIMIT $4
+			FOR UPDATE OF o SKIP LOCKED
+		)
+		UPDATE notification_outbox o
+		SET next_attempt_at = $3
+		FROM due
+		WHERE o.id = due.id
+		RETURNING o.id, o.user_id, o.kind, o.payload, o.attempts, due.stale;
+	`
+	getPreferenceQuery = `
+		SELECT channel, target, enabled
+		FROM notification_preference
+		WHERE user_id = $1 AND kind = $2;
+	`
+	markSentQuery = `
+		UPDATE notification_outbox
+		SET sent_at = $1, attempts = attempts + 1, last_error = $2
+		WHERE id = ANY($3) AND sent_at IS NULL;
+	`
+	markSkippedQuery = `
+		UPDATE notification_outbox
+		SET sent_at = $1
+		WHERE id = ANY($2) AND sent_at IS NULL;
+	`
+	markFailedQuery = `
+		UPDATE notification_outbox
+		SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2
+		WHERE id = ANY($3) AND sent_at IS NULL;
+	`
+)
+
+type DatabaseInterface interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// PostgresNotificationStore keeps the preferences and the outbox in the
+// notification_preference and notification_outbox tables.
+type PostgresNotificationStore struct {
+	db DatabaseInterface
+}
+
+func NewPostgresNotificationStore(db DatabaseInterface) *PostgresNotificationStore {
+	return &PostgresNotificationStore{
+		db: db,
+	}
+}
+
+func (s *PostgresNotificationStore) Preferences(ctx context.Context, userID string) ([]*Preference, error) {
+	rows, err := s.db.Query(ctx, getPreferencesQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*Preference, 0)
+	for rows.Next() {
+		var p Preference
+		if err := rows.Scan(&p.Kind, &p.Channel, &p.Target, &p.Enabled); err != nil {
+			return nil, err
+		}
+		items = append(items, &p)
+	}
+	return items, rows.Err()
+}
+
+func (s *PostgresNotificationStore) UpsertPreference(ctx context.Context, userID string, p *Preference) error {
+	_, err := s.db.Exec(ctx, upsertPreferenceQuery, userID, p.Kind, p.Channel, p.Target, p.Enabled)
+	return err
+}
+
+func (s *PostgresNotificationStore) SaveSearch(ctx context.Context, search *SavedSearch, limit int) (bool, error) {
+	tag, err := s.db.Exec(ctx, saveSearchQuery, search.Id, search.UserId, search.Name, search.Type, search.City,
+		search.CreatedAt.AsTime(), limit)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *PostgresNotificationStore) SavedSearches(ctx context.Context, userID string) ([]*SavedSearch, error) {
+	rows, err := s.db.Query(ctx, listSavedSearchesQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*SavedSearch, 0)
+	for rows.Next() {
+		var (
+			search    SavedSearch
+			createdAt time.Time
+		)
+		if err := rows.Scan(&search.Id, &search.UserId, &search.Name, &search.Type, &search.City, &createdAt); err != nil {
+			return nil, err
+		}
+		search.CreatedAt = timestamppb.New(createdAt)
+		items = append(items, &search)
+	}
+	return items, rows.Err()
+}
+
+func (s *PostgresNotificationStore) DeleteSavedSearch(ctx context.Context, id string, userID string) (bool, error) {
+	tag, err := s.db.Exec(ctx, deleteSavedSearchQuery, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *PostgresNotificationStore) Enqueue(ctx context.Context, userID string, kind string, subjectID string, payload json.RawMessage, at time.Time) error {
+	var subject any
+	if subjectID != "" {
+		subject = subjectID
+	}
+	_, err := s.db.Exec(ctx, EnqueueQuery, userID, kind, subject, payload, at)
+	return err
+}
+
+// ClaimOutbox sorts the leased rows itself, RETURNING does not keep the order of the claim.
+func (s *PostgresNotificationStore) ClaimOutbox(ctx context.Context, createdBefore time.Time, now time.Time, leaseUntil time.Time, limit int) ([]OutboxItem, error) {
+	rows, err := s.db.Query(ctx, claimOutboxQuery, createdBefore, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]OutboxItem, 0)
+	for rows.Next() {
+		var it OutboxItem
+		if err := rows.Scan(&it.ID, &it.UserID, &it.Kind, &it.Payload, &it.Attempts, &it.Stale); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// ids grow with the creation time
+	slices.SortFunc(items, func(a, b OutboxItem) int {
+		return cmp.Or(cmp.Compare(a.UserID, b.UserID), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.ID, b.ID))
+	})
+	return items, nil
+}
+
+func (s *PostgresNotificationStore) Preference(ctx context.Context, userID string, kind string) (*Preference, error) {
+	p := &Preference{Kind: kind}
+	err := s.db.QueryRow(ctx, getPreferenceQuery, userID, kind).Scan(&p.Channel, &p.Target, &p.Enabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *PostgresNotificationStore) MarkSkipped(ctx context.Context, ids []int64, at time.Time) error {
+	_, err := s.db.Exec(ctx, markSkippedQuery, at, ids)
+	return err
+}
+
+func (s *PostgresNotificationStore) MarkSent(ctx context.Context, ids []int64, at time.Time, note string) error {
+	var lastError any
+	if note != "" {
+		lastError = note
+	}
+	_, err := s.db.Exec(ctx, markSentQuery, at, lastError, ids)
+	return err
+}
+
+func (s *PostgresNotificationStore) MarkFailed(ctx context.Context, ids []int64, lastError string, next time.Time) error {
+	_, err := s.db.Exec(ctx, markFailedQuery, lastError, next, ids)
+	return err
+}
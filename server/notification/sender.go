@@ -0,0 +1,161 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"lovco/server/safehttp"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Sender delivers a notification on one channel.
+type Sender interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// LogSender writes notifications to the log, it is the default channel in development.
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, n Notification) error {
+	s.logger.Info("notification", "user_id", n.UserID, "kind", n.Kind, "subject", n.Subject, "items", len(n.Items))
+	return nil
+}
+
+// SMTPSender mails notifications through a plain SMTP relay, e.g. MailHog in development.
+type SMTPSender struct {
+	addr string
+	from string
+}
+
+func NewSMTPSender(addr string, from string) *SMTPSender {
+	return &SMTPSender{addr: addr, from: from}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, n Notification) (err error) {
+	if n.Target == "" {
+		return fmt.Errorf("no email address for user %s", n.UserID)
+	}
+	// only the bare address goes into the SMTP commands and the header, never a display name
+	to, err := mail.ParseAddress(n.Target)
+	if err != nil {
+		return fmt.Errorf("invalid email address for user %s: %w", n.UserID, err)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to.Address)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", n.Subject)
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(n.Body, "\n", "\r\n"))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// net/smtp takes no context, a relay that stops answering must not outlast the timeout and the lease
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer func() {
+		// report the timeout rather than the connection it closed
+		if !stop() && err != nil {
+			err = ctx.Err()
+		}
+	}()
+
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// like smtp.SendMail, upgrade the connection when the relay offers it
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(msg.String())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// WebhookSender posts notifications as JSON to the URL the user configured.
+// Like webhook deliveries, it refuses internal addresses unless allowPrivate is set.
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(timeout time.Duration, allowPrivate bool) *WebhookSender {
+	return &WebhookSender{client: safehttp.NewClient(timeout, allowPrivate)}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, n Notification) error {
+	if n.Target == "" {
+		return fmt.Errorf("no webhook url for user %s", n.UserID)
+	}
+
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.Target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if errors.Is(err, safehttp.ErrNotAllowed) {
+		// the error is stored with the item, it does not tell what the name resolved to
+		return safehttp.ErrNotAllowed
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"fmt"
+	"lovco/server/leftover"
+	"lovco/server/validate"
+	"net/mail"
+)
+
+// AddRules declares the rules the requests of the notification service must follow.
+func AddRules(rules validate.Rules) {
+	rules.Add(&PreferencesRequest{}, validate.ID("user_id"))
+
+	rules.Add(&Preferences{},
+		validate.ID("user_id"),
+		validate.Required("items.kind"),
+		validate.OneOf("items.kind", kinds...),
+		validate.Required("items.channel"),
+		validate.OneOf("items.channel", channels...),
+		validate.MaxLen("items.target", 255),
+		validate.Check(func(req *Preferences, v *validate.Violations) {
+			for i, p := range req.Items {
+				field := fmt.Sprintf("items[%d].target", i)
+				switch {
+				case p.Target == "":
+					if p.Enabled && p.Channel != ChannelLog && p.Channel != "" {
+						v.Add(field, validate.ReasonRequired, fmt.Sprintf("%s notifications need a target", p.Channel))
+					}
+				case p.Channel == ChannelEmail:
+					if _, err := mail.ParseAddress(p.Target); err != nil {
+						v.Add(field, validate.ReasonInvalidFormat, "must be an email address")
+					}
+				case p.Channel == ChannelWebhook:
+					if !validate.IsHTTPURL(p.Target) {
+						v.Add(field, validate.ReasonInvalidFormat, "must be an absolute http or https URL")
+					}
+				}
+			}
+		}),
+	)
+
+	rules.Add(&SavedSearch{},
+		validate.ID("user_id"),
+		validate.MaxLen("name", 255),
+		validate.OneOf("type", leftover.Types...),
+		validate.MaxLen("city", 255),
+		validate.Check(func(req *SavedSearch, v *validate.Violations) {
+			if req.Name == nil && req.Type == nil && req.City == nil {
+				v.Add("name", validate.ReasonRequired, "a saved search needs a name, type or city")
+			}
+		}),
+	)
+
+	rules.Add(&SavedSearchesRequest{}, validate.ID("user_id"))
+
+	rules.Add(&SavedSearchIdentity{},
+		validate.ID("id"),
+		validate.ID("user_id"),
+	)
+}
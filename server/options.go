@@ -0,0 +1,318 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"lovco/server/chat"
+	"lovco/server/config"
+	"lovco/server/healthcheck"
+	"lovco/server/leftover"
+	"lovco/server/notification"
+	"lovco/server/pubsub"
+	"lovco/server/storage"
+	"lovco/server/webhook"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Service names a lovco gRPC service, it is also the service name reported by the health service.
+type Service string
+
+const (
+	LeftoverService     Service = "LeftoverService"
+	ChatService         Service = "ChatService"
+	NotificationService Service = "NotificationService"
+	WebhookService      Service = "WebhookService"
+)
+
+var allServices = []Service{LeftoverService, ChatService, NotificationService, WebhookService}
+
+// Stores are the storage backends of the services.
+type Stores struct {
+	Leftovers     leftover.LeftoverStore
+	Chats         chat.ChatStore
+	Notifications notification.NotificationStore
+	Webhooks      webhook.WebhookStore
+}
+
+// Option configures a Server in New.
+type Option func(*options)
+
+type options struct {
+	address    string
+	listener   net.Listener
+	db         *pgxpool.Pool
+	stores     *Stores
+	broker     pubsub.Broker
+	logger     *slog.Logger
+	tls        *tls.Config
+	unary      []grpc.UnaryServerInterceptor
+	stream     []grpc.StreamServerInterceptor
+	grpcOpts   []grpc.ServerOption
+	services   []Service
+	reflection bool
+
+	health          bool
+	healthOpts      healthcheck.Options
+	keepalive       keepalive.ServerParameters
+	keepalivePolicy keepalive.EnforcementPolicy
+	drainTimeout    time.Duration
+	metricsAddress  string
+	crashDir        string
+
+	chat        chat.Options
+	senders     map[string]notification.Sender // nil sends on the log, SMTP and webhook channels
+	smtpAddr    string
+	smtpFrom    string
+	notifyOpts  notification.DispatcherOptions
+	webhookOpts webhook.DispatcherOptions
+}
+
+func defaultOptions() *options {
+	o := &options{
+		logger:     slog.Default(),
+		services:   allServices,
+		reflection: true,
+		health:     true,
+	}
+	WithConfig(config.Default())(o)
+	// an embedding binary serves metrics on its own port or not at all
+	o.metricsAddress = ""
+	return o
+}
+
+// WithConfig takes the listener address, timeouts and the tuning of the services from cfg.
+// The database and storage settings are not used, pass the stores with WithDB or WithStores.
+func WithConfig(cfg *config.Config) Option {
+	return func(o *options) {
+		o.address = fmt.Sprintf("%s:%d", cfg.Server.Address, cfg.Server.Port)
+		o.drainTimeout = cfg.Server.DrainTimeout
+		o.metricsAddress = cfg.Metrics.Address
+		o.crashDir = cfg.Server.CrashDir
+		// pings find dead peers of long lived chat streams, their context ends and the seat is released
+		o.keepalive = keepalive.ServerParameters{
+			Time:    cfg.Server.KeepaliveTime,
+			Timeout: cfg.Server.KeepaliveTimeout,
+		}
+		o.keepalivePolicy = keepalive.EnforcementPolicy{
+			MinTime:             cfg.Server.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}
+		o.healthOpts = healthcheck.Options{
+			Interval:         cfg.Health.Interval,
+			Timeout:          cfg.Health.Timeout,
+			FailureThreshold: cfg.Health.Failures,
+		}
+		o.chat = chat.Options{
+			EditWindow:     cfg.Chat.EditWindow,
+			ReconnectGrace: cfg.Chat.ReconnectGrace,
+			RestoreWindow:  cfg.Chat.RestoreWindow,
+			MaxQueueLength: cfg.Chat.MaxQueue,
+			MaxQueueWait:   cfg.Chat.MaxQueueWait,
+			Admins:         cfg.Chat.Admins,
+			UserSendRate:   cfg.Chat.SendRate,
+			UserSendBurst:  cfg.Chat.SendBurst,
+			RoomSendRate:   cfg.Chat.RoomSendRate,
+			RoomSendBurst:  cfg.Chat.RoomSendBurst,
+			SpamWindow:     cfg.Chat.SpamWindow,
+			SpamRepeats:    cfg.Chat.SpamRepeats,
+			SpamLinks:      cfg.Chat.SpamLinks,
+			MuteDuration:   cfg.Chat.Mute,
+
+			HeartbeatInterval: cfg.Chat.Heartbeat,
+		}
+		o.smtpAddr = cfg.Notify.SMTPAddr
+		o.smtpFrom = cfg.Notify.SMTPFrom
+		o.notifyOpts = notification.DispatcherOptions{
+			Interval:       cfg.Notify.Interval,
+			BatchWindow:    cfg.Notify.BatchWindow,
+			BatchSize:      100,
+			MaxAttempts:    8,
+			Timeout:        10 * time.Second,
+			DefaultChannel: cfg.Notify.Channel,
+		}
+		o.webhookOpts = webhook.DispatcherOptions{
+			Interval:            cfg.Webhook.Interval,
+			BatchSize:           50,
+			MaxAttempts:         cfg.Webhook.MaxAttempts,
+			Timeout:             10 * time.Second,
+			AllowPrivateTargets: cfg.Webhook.AllowPrivateTargets,
+		}
+	}
+}
+
+// WithAddress sets the host:port to listen on, "0.0.0.0:50051" by default.
+func WithAddress(address string) Option {
+	return func(o *options) {
+		o.address = address
+	}
+}
+
+// WithListener serves on lis instead of listening on the address.
+func WithListener(lis net.Listener) Option {
+	return func(o *options) {
+		o.listener = lis
+	}
+}
+
+// WithDB keeps everything in Postgres and reports the database in the health checks.
+// The caller owns db and closes it after Shutdown.
+func WithDB(db *pgxpool.Pool) Option {
+	return func(o *options) {
+		o.db = db
+		o.stores = &Stores{
+			Leftovers:     leftover.NewPostgresLeftoverStore(db),
+			Chats:         chat.NewPostgresChatStore(db),
+			Notifications: notification.NewPostgresNotificationStore(db),
+			Webhooks:      webhook.NewPostgresWebhookStore(db),
+		}
+	}
+}
+
+// WithStores uses stores instead of a database.
+func WithStores(stores Stores) Option {
+	return func(o *options) {
+		o.db = nil
+		o.stores = &stores
+	}
+}
+
+// WithMemoryStore keeps everything in memory, nothing survives a restart.
+func WithMemoryStore() Option {
+	mem := storage.NewMemoryStore()
+	return WithStores(Stores{
+		Leftovers:     mem.Leftovers(),
+		Chats:         mem.Chats(),
+		Notifications: mem.Notifications(),
+		Webhooks:      mem.Webhooks(),
+	})
+}
+
+// WithBroker carries the chat room events between replicas, a pubsub.MemoryBroker by default.
+func WithBroker(broker pubsub.Broker) Option {
+	return func(o *options) {
+		o.broker = broker
+	}
+}
+
+// WithLogger sets the logger of the server, its interceptors and dispatchers, slog.Default by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithTLS serves over TLS with cfg.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tls = cfg
+	}
+}
+
+// WithUnaryInterceptors adds interceptors that run after the built in ones, in the given order.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *options) {
+		o.unary = append(o.unary, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds interceptors that run after the built in ones, in the given order.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *options) {
+		o.stream = append(o.stream, interceptors...)
+	}
+}
+
+// WithGRPCOptions passes any other option to grpc.NewServer.
+func WithGRPCOptions(opts ...grpc.ServerOption) Option {
+	return func(o *options) {
+		o.grpcOpts = append(o.grpcOpts, opts...)
+	}
+}
+
+// WithServices registers only services instead of all of them. The dispatchers run either way,
+// chat messages queue notifications and leftovers queue webhook deliveries.
+func WithServices(services ...Service) Option {
+	return func(o *options) {
+		o.services = services
+	}
+}
+
+// WithReflection turns the gRPC reflection service on or off, it is on by default.
+func WithReflection(enabled bool) Option {
+	return func(o *options) {
+		o.reflection = enabled
+	}
+}
+
+// WithHealth tunes the checks behind the gRPC health service.
+func WithHealth(opts healthcheck.Options) Option {
+	return func(o *options) {
+		o.health = true
+		o.healthOpts = opts
+	}
+}
+
+// WithoutHealth leaves out the gRPC health service, e.g. when the embedding binary has its own.
+func WithoutHealth() Option {
+	return func(o *options) {
+		o.health = false
+	}
+}
+
+// WithDrainTimeout bounds how long Serve waits for open calls once its context is done.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.drainTimeout = d
+	}
+}
+
+// WithMetricsAddress serves Prometheus metrics on /metrics at address, an empty address turns it off.
+// It is off by default, the metrics are collected either way and Server.Metrics hands them out.
+func WithMetricsAddress(address string) Option {
+	return func(o *options) {
+		o.metricsAddress = address
+	}
+}
+
+// WithCrashReports writes a report file to dir for every handler panic, next to logging it.
+// The reports hold the request, so dir should only be readable by the operators.
+func WithCrashReports(dir string) Option {
+	return func(o *options) {
+		o.crashDir = dir
+	}
+}
+
+// WithChatOptions tunes the chat rooms, without a Logger they log with the logger of the server.
+func WithChatOptions(opts chat.Options) Option {
+	return func(o *options) {
+		o.chat = opts
+	}
+}
+
+// WithNotificationSenders replaces the senders of the notification channels,
+// notifications on a channel without a sender fail.
+func WithNotificationSenders(senders map[string]notification.Sender) Option {
+	return func(o *options) {
+		o.senders = senders
+	}
+}
+
+// WithNotificationDispatcher tunes the notification dispatcher.
+func WithNotificationDispatcher(opts notification.DispatcherOptions) Option {
+	return func(o *options) {
+		o.notifyOpts = opts
+	}
+}
+
+// WithWebhookDispatcher tunes the webhook dispatcher.
+func WithWebhookDispatcher(opts webhook.DispatcherOptions) Option {
+	return func(o *options) {
+		o.webhookOpts = opts
+	}
+}
@@ -0,0 +1,118 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxPayload is the NOTIFY payload limit of a default Postgres build.
+const maxPayload = 8000
+
+// PostgresBroker is a Broker built on Postgres LISTEN/NOTIFY, so every replica
+// connected to the same database sees the same stream of payloads.
+// Topics are used as channel names.
+// Payloads published while a subscriber is reconnecting are lost for that subscriber.
+type PostgresBroker struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+	down   atomic.Int32 // subscriptions that are reconnecting
+}
+
+func NewPostgresBroker(pool *pgxpool.Pool, logger *slog.Logger) *PostgresBroker {
+	return &PostgresBroker{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+func (b *PostgresBroker) Publish(ctx context.Context, topic string, payload []byte) error {
+	if len(payload) > maxPayload {
+		return ErrPayloadTooLarge
+	}
+	_, err := b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", topic, string(payload))
+	return err
+}
+
+func (b *PostgresBroker) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	go func() {
+		defer close(ch)
+		down := false
+		defer func() {
+			if down {
+				b.down.Add(-1)
+			}
+		}()
+
+		backoff := 100 * time.Millisecond
+		for {
+			err := b.listen(ctx, topic, ch, func() {
+				if down {
+					b.down.Add(-1)
+					down = false
+				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if !down {
+				b.down.Add(1)
+				down = true
+			}
+			b.logger.Error("pubsub listener failed, reconnecting", "topic", topic, "error", err, "backoff", backoff)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, 10*time.Second)
+		}
+	}()
+
+	return ch, nil
+}
+
+// Check fails while a subscription is reconnecting, its payloads are lost meanwhile.
+func (b *PostgresBroker) Check(ctx context.Context) error {
+	if n := b.down.Load(); n > 0 {
+		return fmt.Errorf("pubsub: %d listeners are reconnecting", n)
+	}
+	return nil
+}
+
+// listen holds a dedicated connection outside of the pool for as long as the subscription lives.
+// listening is called once the connection listens on topic.
+func (b *PostgresBroker) listen(ctx context.Context, topic string, ch chan<- []byte, listening func()) error {
+	pooled, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{topic}.Sanitize()); err != nil {
+		return err
+	}
+	b.logger.Info("pubsub listening", "topic", topic)
+	listening()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		select {
+		case ch <- []byte(n.Payload):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
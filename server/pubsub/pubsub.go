@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrPayloadTooLarge = errors.New("pubsub: payload too large")
+
+// Broker delivers every payload published on a topic to all subscribers of that topic,
+// including the subscribers living in the publishing process.
+// Payloads are delivered to a subscriber in the order the broker accepted them.
+type Broker interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe returns a channel of payloads published on topic.
+	// The channel is closed once ctx is done.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
+
+// Checker is implemented by brokers that depend on something that can fail, like a connection.
+type Checker interface {
+	// Check returns an error while the broker cannot deliver payloads.
+	Check(ctx context.Context) error
+}
+
+// subscriberBuffer is how many payloads can wait for a slow subscriber before Publish blocks.
+const subscriberBuffer = 256
+
+// MemoryBroker is a Broker for a single process.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	topics map[string]map[chan []byte]struct{}
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		topics: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, topic string, payload []byte) error {
+	// holding the lock while sending keeps the same order for every subscriber
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.topics[topic] {
+		select {
+		case ch <- payload:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[chan []byte]struct{})
+	}
+	b.topics[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.topics[topic], ch)
+		b.mu.Unlock()
+		close(ch)
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"lovco/server/logging"
+	"lovco/server/metrics"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// sensitive are parts of the names of request fields left out of crash reports: credentials,
+// and where notifications and webhooks go, which can be an email address or carry a token.
+var sensitive = []string{"secret", "token", "password", "email", "target", "url"}
+
+// recovered reports the panic p of a call to method and returns the error for the caller.
+// The error id is in the message and in an ErrorInfo detail, the logs and the crash report
+// carry the same id so a user quoting it can be looked up. No crash report is written without crashDir.
+func recovered(ctx context.Context, m *metrics.Metrics, crashDir string, method string, req any, p any) error {
+	id := uuid.NewString()
+	stack := debug.Stack()
+	m.Panicked(method)
+
+	logger := logging.FromContext(ctx)
+	logger.Error("handler panicked", "error_id", id, "panic", fmt.Sprint(p), "stack", string(stack))
+	if crashDir != "" {
+		path, err := writeCrashReport(ctx, crashDir, id, method, req, p, stack)
+		if err != nil {
+			logger.Error("failed to write crash report", "error_id", id, "error", err)
+		} else {
+			logger.Info("crash report written", "error_id", id, "path", path)
+		}
+	}
+
+	st := status.New(codes.Internal, "internal error, reference "+id)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: "PANIC",
+		Domain: "lovco",
+		Metadata: map[string]string{
+			"error_id":   id,
+			"request_id": logging.RequestID(ctx),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// writeCrashReport writes everything known about the panic to a file of its own in the crash directory.
+// The request is included with its sensitive fields redacted, still the files are only readable by the owner.
+func writeCrashReport(ctx context.Context, dir string, id string, method string, req any, p any, stack []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	var b strings.Builder
+	fmt.Fprintf(&b, "error id:   %s\n", id)
+	fmt.Fprintf(&b, "time:       %s\n", now.Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, "method:     %s\n", method)
+	fmt.Fprintf(&b, "request id: %s\n", logging.RequestID(ctx))
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fmt.Fprintf(&b, "trace id:   %s\n", sc.TraceID())
+	}
+	fmt.Fprintf(&b, "panic:      %v\n", p)
+	if msg, ok := req.(proto.Message); ok {
+		data, err := protojson.Marshal(redact(msg))
+		if err != nil {
+			data = []byte(err.Error())
+		}
+		fmt.Fprintf(&b, "request:    %T %s\n", req, data)
+	}
+	fmt.Fprintf(&b, "\n%s", stack)
+
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s-%s.txt", now.Format("20060102T150405Z"), id))
+	return path, os.WriteFile(path, []byte(b.String()), 0o600)
+}
+
+// redact returns a copy of msg whose sensitive string fields, in nested messages too, read REDACTED.
+func redact(msg proto.Message) proto.Message {
+	msg = proto.Clone(msg)
+	redactMessage(msg.ProtoReflect())
+	return msg
+}
+
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					redactMessage(value.Message())
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.StringKind && isSensitive(fd.Name()):
+			if fd.IsList() {
+				list := v.List()
+				for i := range list.Len() {
+					list.Set(i, protoreflect.ValueOfString("REDACTED"))
+				}
+			} else {
+				m.Set(fd, protoreflect.ValueOfString("REDACTED"))
+			}
+		case fd.Message() != nil:
+			if fd.IsList() {
+				list := v.List()
+				for i := range list.Len() {
+					redactMessage(list.Get(i).Message())
+				}
+			} else {
+				redactMessage(v.Message())
+			}
+		}
+		return true
+	})
+}
+
+func isSensitive(name protoreflect.Name) bool {
+	lower := strings.ToLower(string(name))
+	for _, part := range sensitive {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// recoveryUnaryInterceptor turns the panics of unary handlers into Internal errors instead of
+// crashing the server and every chat stream with it. Panics in goroutines started by a handler are not caught.
+func recoveryUnaryInterceptor(m *metrics.Metrics, crashDir string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, recovered(ctx, m, crashDir, info.FullMethod, req, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor is recoveryUnaryInterceptor for streams. The request of a stream
+// is read by the handler, so the crash report does not have it.
+func recoveryStreamInterceptor(m *metrics.Metrics, crashDir string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				// the logging interceptor adds the ids of the request to the context once it is read
+				err = recovered(ss.Context(), m, crashDir, info.FullMethod, nil, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,87 @@
+// Package safehttp builds HTTP clients for URLs chosen by users, like webhooks. Their requests
+// are refused when the destination resolves to a loopback, private, link-local or other internal
+// address, so the server cannot be made to reach the network it runs in, and redirects are not followed.
+package safehttp
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrNotAllowed is returned for requests to an address that is not allowed. It is the same
+// for every refused address, so what a name resolves to does not show in delivery errors.
+var ErrNotAllowed = errors.New("destination address is not allowed")
+
+// blocked are the ranges netip has no predicate for: "this network", shared address space (CGNAT),
+// IETF protocol assignments, benchmarking, reserved and NAT64, which can map to any IPv4 address.
+var blocked = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// Allowed tells if requests may be sent to ip.
+func Allowed(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, prefix := range blocked {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewClient returns a client whose requests time out after timeout. allowPrivate lets it reach
+// internal addresses too, for receivers running next to the server during development.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = control
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// a proxy would connect to the destination on our behalf, past the check of the dialer
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+		// the answer to the first request is the answer, a redirect is not a success either
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// control checks the address a connection is about to be made to. It runs after the name was
+// resolved, so a name resolving to an internal address is refused whatever it resolved to before.
+func control(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrNotAllowed
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || !Allowed(ip) {
+		return ErrNotAllowed
+	}
+	return nil
+}
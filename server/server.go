@@ -0,0 +1,285 @@
+// Package server wires the lovco services into a gRPC server, so they can run on their own
+// through server/cmd or be embedded in another binary next to other gRPC services.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lovco/server/chat"
+	"lovco/server/healthcheck"
+	"lovco/server/leftover"
+	"lovco/server/metrics"
+	"lovco/server/notification"
+	"lovco/server/pubsub"
+	"lovco/server/webhook"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server runs the lovco services and their background work: the health checks,
+// the notification and webhook dispatchers and the metrics listener.
+type Server struct {
+	opts       options
+	lis        net.Listener
+	metricsLis net.Listener
+	grpc       *grpc.Server
+	metrics    *metrics.Metrics
+	monitor    *healthcheck.Monitor
+	chat       *chat.ChatServer
+	notifier   *notification.Dispatcher
+	webhooks   *webhook.Dispatcher
+
+	monitorCtx   context.Context
+	stopMonitor  context.CancelFunc
+	dispatchCtx  context.Context
+	stopDispatch context.CancelFunc
+
+	shutdownOnce sync.Once
+	shutdownErr  error
+}
+
+// New builds a Server. Storage must be given with WithDB, WithStores or WithMemoryStore,
+// everything else has defaults. New listens right away, so Addr is known before Serve.
+// Calls and SQL statements are traced with the global OpenTelemetry tracer provider, see tracing.Setup.
+func New(opts ...Option) (*Server, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.stores == nil {
+		return nil, errors.New("server: no storage, use WithDB, WithStores or WithMemoryStore")
+	}
+	for _, service := range o.services {
+		if !slices.Contains(allServices, service) {
+			return nil, fmt.Errorf("server: unknown service %q", service)
+		}
+	}
+	if o.broker == nil {
+		o.broker = pubsub.NewMemoryBroker()
+	}
+	if o.chat.Logger == nil {
+		o.chat.Logger = o.logger
+	}
+	if o.senders == nil {
+		o.senders = map[string]notification.Sender{
+			notification.ChannelLog:     notification.NewLogSender(o.logger),
+			notification.ChannelEmail:   notification.NewSMTPSender(o.smtpAddr, o.smtpFrom),
+			notification.ChannelWebhook: notification.NewWebhookSender(10*time.Second, o.webhookOpts.AllowPrivateTargets),
+		}
+	}
+
+	lis := o.listener
+	if lis == nil {
+		var err error
+		lis, err = net.Listen("tcp", o.address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var metricsLis net.Listener
+	if o.metricsAddress != "" {
+		var err error
+		metricsLis, err = net.Listen("tcp", o.metricsAddress)
+		if err != nil {
+			if o.listener == nil {
+				lis.Close()
+			}
+			return nil, err
+		}
+	}
+
+	m := metrics.New()
+	rules := validationRules()
+	grpcOpts := []grpc.ServerOption{
+		// the recovery runs after tracing and logging, so a panic is logged, counted and traced like any other error.
+		// Requests are validated last, the interceptors of the embedder only see valid ones
+		grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+			tracingUnaryInterceptor(),
+			loggingUnaryInterceptor(o.logger, m),
+			recoveryUnaryInterceptor(m, o.crashDir),
+			validationUnaryInterceptor(rules),
+		}, o.unary...)...),
+		grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{
+			tracingStreamInterceptor(),
+			loggingStreamInterceptor(o.logger, m),
+			recoveryStreamInterceptor(m, o.crashDir),
+			validationStreamInterceptor(rules),
+		}, o.stream...)...),
+		grpc.KeepaliveParams(o.keepalive),
+		grpc.KeepaliveEnforcementPolicy(o.keepalivePolicy),
+	}
+	if o.tls != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(o.tls)))
+	}
+	srv := grpc.NewServer(append(grpcOpts, o.grpcOpts...)...)
+
+	s := &Server{
+		opts:       *o,
+		lis:        lis,
+		metricsLis: metricsLis,
+		grpc:       srv,
+		metrics:    m,
+	}
+	if o.db != nil {
+		m.MustRegister(metrics.NewPoolCollector(o.db))
+	}
+	s.monitorCtx, s.stopMonitor = context.WithCancel(context.Background())
+	s.dispatchCtx, s.stopDispatch = context.WithCancel(context.Background())
+
+	if o.reflection {
+		reflection.Register(srv)
+	}
+
+	var storageChecks, chatChecks []string
+	if o.health {
+		healthServer := health.NewServer()
+		healthgrpc.RegisterHealthServer(srv, healthServer)
+		s.monitor = healthcheck.NewMonitor(healthServer, o.healthOpts, o.logger)
+
+		// the memory store cannot fail, the services only depend on the database when there is one
+		if o.db != nil {
+			s.monitor.AddCheck("database", o.db.Ping)
+			storageChecks = append(storageChecks, "database")
+		}
+		chatChecks = append(chatChecks, storageChecks...)
+		if checker, ok := o.broker.(pubsub.Checker); ok {
+			s.monitor.AddCheck("pubsub", checker.Check)
+			chatChecks = append(chatChecks, "pubsub")
+		}
+	}
+
+	stores := o.stores
+	for _, service := range o.services {
+		checks := storageChecks
+		switch service {
+		case LeftoverService:
+			leftover.RegisterLeftoverServiceServer(srv, leftover.NewLeftoverServer(stores.Leftovers, stores.Webhooks))
+		case ChatService:
+			s.chat = chat.NewChatServer(stores.Chats, stores.Webhooks, o.broker, o.chat)
+			chat.RegisterChatServiceServer(srv, s.chat)
+			m.MustRegister(metrics.NewChatCollector(s.chat.Stats))
+			checks = chatChecks
+		case NotificationService:
+			notification.RegisterNotificationServiceServer(srv, notification.NewNotificationServer(stores.Notifications))
+		case WebhookService:
+			webhook.RegisterWebhookServiceServer(srv, webhook.NewWebhookServer(stores.Webhooks))
+		}
+		if s.monitor != nil {
+			s.monitor.AddService(string(service), checks...)
+		}
+	}
+
+	s.notifier = notification.NewDispatcher(stores.Notifications, o.senders, o.notifyOpts, o.logger)
+	s.webhooks = webhook.NewDispatcher(stores.Webhooks, o.webhookOpts, o.logger)
+
+	return s, nil
+}
+
+// GRPCServer returns the underlying gRPC server, register other services on it before Serve.
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.grpc
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.lis.Addr()
+}
+
+// Metrics returns the metrics of the server, e.g. to serve them next to those of the embedding binary.
+// Register more collectors on it before Serve.
+func (s *Server) Metrics() *metrics.Metrics {
+	return s.metrics
+}
+
+// Serve runs the server until ctx is done or Shutdown is called. When ctx is done it shuts
+// the server down itself, giving open calls the drain timeout to finish.
+func (s *Server) Serve(ctx context.Context) error {
+	if s.monitor != nil {
+		go s.monitor.Run(s.monitorCtx)
+	}
+	go s.notifier.Run(s.dispatchCtx)
+	go s.webhooks.Run(s.dispatchCtx)
+	if s.metricsLis != nil {
+		// metrics stay up while the server drains and stop with the dispatchers
+		go func() {
+			if err := s.metrics.Serve(s.dispatchCtx, s.metricsLis, s.opts.logger); err != nil {
+				s.opts.logger.Error("Metrics server failed", "error", err)
+			}
+		}()
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- s.grpc.Serve(s.lis)
+	}()
+	s.opts.logger.Info("Serving", "address", s.lis.Addr().String())
+
+	select {
+	case err := <-served:
+		// Shutdown was called or the listener failed
+		s.stopMonitor()
+		s.stopDispatch()
+		if s.chat != nil {
+			s.chat.Stop()
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.opts.drainTimeout)
+		defer cancel()
+		err := s.Shutdown(shutdownCtx)
+		<-served
+		return err
+	}
+}
+
+// Shutdown stops the server: load balancers are told first, chat and queue streams are asked
+// to reconnect elsewhere, then open calls may finish until ctx is done and are cut off after that.
+// The background work stops last. The database given with WithDB is left open.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.opts.logger.Info("Shutting down server...")
+
+		// load balancers stop sending new calls first
+		if s.monitor != nil {
+			s.monitor.Shutdown()
+		}
+		s.stopMonitor()
+
+		// chat and queue streams never end on their own, tell their clients to reconnect elsewhere
+		if s.chat != nil {
+			s.chat.Drain()
+		}
+
+		stopped := make(chan struct{})
+		go func() {
+			s.grpc.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			s.opts.logger.Info("Server gracefully stopped")
+		case <-ctx.Done():
+			s.opts.logger.Warn("Drain deadline passed, closing remaining connections", "drain_timeout", s.opts.drainTimeout)
+			s.grpc.Stop()
+			<-stopped
+			s.shutdownErr = ctx.Err()
+		}
+
+		s.stopDispatch()
+		if s.chat != nil {
+			s.chat.Stop()
+		}
+	})
+	return s.shutdownErr
+}
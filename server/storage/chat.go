@@ -0,0 +1,311 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"lovco/server/chat"
+	"lovco/server/notification"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type memoryChatStore struct {
+	m *MemoryStore
+}
+
+func cloneMessage(msg *chat.ChatMessage) *chat.ChatMessage {
+	return proto.Clone(msg).(*chat.ChatMessage)
+}
+
+func (s memoryChatStore) LeftoverOwner(ctx context.Context, leftoverID string) (string, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	lo, ok := s.m.leftovers[leftoverID]
+	if !ok {
+		return "", chat.ErrNotFound
+	}
+	return lo.OwnerId, nil
+}
+
+func (s memoryChatStore) OwnedLeftovers(ctx context.Context, ownerID string) ([]string, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	ids := make([]string, 0)
+	for _, id := range s.m.leftoverOrder {
+		if s.m.leftovers[id].OwnerId == ownerID {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s memoryChatStore) IsRefused(ctx context.Context, leftoverID string, userID string) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if s.m.bans[leftoverID][userID] {
+		return true, nil
+	}
+	lo, ok := s.m.leftovers[leftoverID]
+	if !ok {
+		return false, nil
+	}
+	blocked := slices.ContainsFunc(s.m.blocks[lo.OwnerId], func(b blockedUser) bool { return b.id == userID })
+	return blocked, nil
+}
+
+func (s memoryChatStore) IsParticipant(ctx context.Context, leftoverID string, userID string) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if lo, ok := s.m.leftovers[leftoverID]; ok && lo.OwnerId == userID {
+		return true, nil
+	}
+	wrote := slices.ContainsFunc(s.m.history[leftoverID], func(sm *storedMessage) bool { return sm.msg.UserId == userID })
+	return wrote, nil
+}
+
+func (s memoryChatStore) Block(ctx context.Context, userID string, blockedUserID string) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if slices.ContainsFunc(s.m.blocks[userID], func(b blockedUser) bool { return b.id == blockedUserID }) {
+		return nil
+	}
+	s.m.blocks[userID] = append(s.m.blocks[userID], blockedUser{id: blockedUserID, createdAt: time.Now().UTC()})
+	return nil
+}
+
+func (s memoryChatStore) Unblock(ctx context.Context, userID string, blockedUserID string) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	s.m.blocks[userID] = slices.DeleteFunc(s.m.blocks[userID], func(b blockedUser) bool { return b.id == blockedUserID })
+	return nil
+}
+
+func (s memoryChatStore) ListBlocked(ctx context.Context, userID string) ([]*chat.BlockedUser, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	items := make([]*chat.BlockedUser, 0)
+	for _, b := range s.m.blocks[userID] {
+		items = append(items, &chat.BlockedUser{
+			UserId:    b.id,
+			CreatedAt: timestamppb.New(b.createdAt),
+		})
+	}
+	return items, nil
+}
+
+func (s memoryChatStore) Ban(ctx context.Context, leftoverID string, userID string) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if s.m.bans[leftoverID] == nil {
+		s.m.bans[leftoverID] = make(map[string]bool)
+	}
+	s.m.bans[leftoverID][userID] = true
+	return nil
+}
+
+// chatNotification is the outbox payload of a chat message, as the Postgres store builds it.
+type chatNotification struct {
+	LeftoverID string `json:"leftover_id"`
+	From       string `json:"from"`
+	Text       string `json:"text"`
+}
+
+func (s memoryChatStore) AddMessage(ctx context.Context, msg *chat.ChatMessage, recipients []string) error {
+	payload, err := json.Marshal(chatNotification{LeftoverID: msg.LeftoverId, From: msg.UserId, Text: msg.Message})
+	if err != nil {
+		return err
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	sm := &storedMessage{msg: cloneMessage(msg)}
+	// the event only tells the streams what happened, it is not stored
+	sm.msg.Event = chat.ChatEvent_CHAT_EVENT_MESSAGE
+	s.m.messages[msg.Id] = sm
+	s.m.history[msg.LeftoverId] = append(s.m.history[msg.LeftoverId], sm)
+
+	at := msg.CreatedAt.AsTime()
+	for _, uid := range recipients {
+		s.m.enqueue(uid, notification.KindChatMessage, msg.Id, payload, at)
+	}
+	return nil
+}
+
+func (s memoryChatStore) GetMessage(ctx context.Context, leftoverID string, messageID string) (*chat.ChatMessage, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	sm, ok := s.m.messages[messageID]
+	if !ok || sm.msg.LeftoverId != leftoverID {
+		return nil, chat.ErrNotFound
+	}
+	return cloneMessage(sm.msg), nil
+}
+
+// History copies the messages first and calls fn without holding the lock, fn usually sends on a stream.
+func (s memoryChatStore) History(ctx context.Context, leftoverID string, from *time.Time, to *time.Time, fn func(msg *chat.ChatMessage) error) error {
+	s.m.mu.Lock()
+	history := make([]*chat.ChatMessage, 0, len(s.m.history[leftoverID]))
+	for _, sm := range s.m.history[leftoverID] {
+		createdAt := sm.msg.CreatedAt.AsTime()
+		if (from != nil && createdAt.Before(*from)) || (to != nil && !createdAt.Before(*to)) {
+			continue
+		}
+		history = append(history, cloneMessage(sm.msg))
+	}
+	s.m.mu.Unlock()
+
+	for _, msg := range history {
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s memoryChatStore) EditMessage(ctx context.Context, messageID string, message string, image string, at time.Time) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	sm, ok := s.m.messages[messageID]
+	if !ok || sm.deletedAt != nil {
+		return false, nil
+	}
+	sm.msg.Message = message
+	sm.msg.Image = image
+	sm.msg.Edited = true
+	sm.msg.UpdatedAt = timestamppb.New(at)
+	return true, nil
+}
+
+func (s memoryChatStore) DeleteMessage(ctx context.Context, messageID string, at time.Time) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	sm, ok := s.m.messages[messageID]
+	if !ok || sm.deletedAt != nil {
+		return false, nil
+	}
+	sm.msg.Message = ""
+	sm.msg.Image = ""
+	sm.msg.Payload = nil
+	sm.msg.Deleted = true
+	sm.msg.UpdatedAt = timestamppb.New(at)
+	sm.deletedAt = &at
+	return true, nil
+}
+
+func (s memoryChatStore) MarkDelivered(ctx context.Context, messageID string, at time.Time) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if sm, ok := s.m.messages[messageID]; ok && sm.deliveredAt == nil {
+		sm.deliveredAt = &at
+	}
+	return nil
+}
+
+func (s memoryChatStore) MarkHistoryDelivered(ctx context.Context, leftoverID string, userID string, at time.Time) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	for _, sm := range s.m.history[leftoverID] {
+		if sm.msg.UserId != userID && sm.deliveredAt == nil {
+			sm.deliveredAt = &at
+		}
+	}
+	return nil
+}
+
+// decisionNotification is the outbox payload of an answer to a pickup proposal, as the Postgres store builds it.
+type decisionNotification struct {
+	LeftoverID string `json:"leftover_id"`
+	From       string `json:"from"`
+	Status     string `json:"status"`
+	Text       string `json:"text"`
+}
+
+func (s memoryChatStore) AnswerPickup(ctx context.Context, messageID string, pickup *chat.PickupProposal, answeredBy string, at time.Time) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	sm, ok := s.m.messages[messageID]
+	if !ok || sm.deletedAt != nil || sm.msg.GetPickup().GetStatus() != chat.PickupStatus_PICKUP_STATUS_PENDING {
+		return false, nil
+	}
+	sm.msg.Payload = &chat.ChatMessage_Pickup{Pickup: proto.Clone(pickup).(*chat.PickupProposal)}
+	sm.msg.UpdatedAt = timestamppb.New(at)
+
+	// the newest accepted proposal wins
+	if pickup.Status == chat.PickupStatus_PICKUP_STATUS_ACCEPTED {
+		s.m.agreements[sm.msg.LeftoverId] = &chat.AgreedPickup{
+			LeftoverId: sm.msg.LeftoverId,
+			MessageId:  messageID,
+			ProposerId: sm.msg.UserId,
+			AcceptedBy: answeredBy,
+			Start:      pickup.Start,
+			End:        pickup.End,
+			AcceptedAt: timestamppb.New(at),
+		}
+	}
+
+	decision := chat.Decision(pickup.Status)
+	payload, err := json.Marshal(decisionNotification{
+		LeftoverID: sm.msg.LeftoverId,
+		From:       answeredBy,
+		Status:     decision,
+		Text:       "Your pickup proposal was " + decision,
+	})
+	if err != nil {
+		return false, err
+	}
+	s.m.enqueue(sm.msg.UserId, notification.KindClaimDecision, messageID, payload, at)
+	return true, nil
+}
+
+func (s memoryChatStore) AgreedPickup(ctx context.Context, leftoverID string) (*chat.AgreedPickup, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	agreed, ok := s.m.agreements[leftoverID]
+	if !ok {
+		return nil, chat.ErrNotFound
+	}
+	return proto.Clone(agreed).(*chat.AgreedPickup), nil
+}
+
+func (s memoryChatStore) SaveRoom(ctx context.Context, roomID string, snap chat.RoomSnapshot) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if current, ok := s.m.rooms[roomID]; ok && current.SavedAt.After(snap.SavedAt) {
+		return nil
+	}
+	snap.Queue = slices.Clone(snap.Queue)
+	s.m.rooms[roomID] = snap
+	return nil
+}
+
+func (s memoryChatStore) LoadRoom(ctx context.Context, roomID string) (*chat.RoomSnapshot, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	snap, ok := s.m.rooms[roomID]
+	if !ok {
+		return nil, nil
+	}
+	snap.Queue = slices.Clone(snap.Queue)
+	return &snap, nil
+}
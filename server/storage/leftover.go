@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"lovco/server/leftover"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// leftoverTypes are the values of the leftover_type enum.
+var leftoverTypes = []string{"food", "electronic", "clothing", "other"}
+
+type memoryLeftoverStore struct {
+	m *MemoryStore
+}
+
+// stored copies lo the way a row keeps it, a missing point or address is stored empty.
+func stored(lo *leftover.Leftover) *leftover.Leftover {
+	lo = proto.Clone(lo).(*leftover.Leftover)
+	if lo.Coordiantes == nil {
+		lo.Coordiantes = &leftover.Point{}
+	}
+	if lo.Address == nil {
+		lo.Address = &leftover.Address{}
+	}
+	return lo
+}
+
+func (s memoryLeftoverStore) Add(ctx context.Context, lo *leftover.Leftover) error {
+	if !slices.Contains(leftoverTypes, lo.Type) {
+		return fmt.Errorf("invalid leftover type %q", lo.Type)
+	}
+
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if _, ok := s.m.leftovers[lo.Id]; ok {
+		return fmt.Errorf("leftover %s already exists", lo.Id)
+	}
+	s.m.leftovers[lo.Id] = stored(lo)
+	s.m.leftoverOrder = append(s.m.leftoverOrder, lo.Id)
+	return s.m.notifySearches(lo, time.Now().UTC())
+}
+
+func (s memoryLeftoverStore) Get(ctx context.Context, id string) (*leftover.Leftover, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	lo, ok := s.m.leftovers[id]
+	if !ok {
+		return nil, leftover.ErrNotFound
+	}
+	return proto.Clone(lo).(*leftover.Leftover), nil
+}
+
+// matches applies the filters of a search the way the Postgres store builds its WHERE clause.
+func matches(lo *leftover.Leftover, q *leftover.LeftoverQuery) bool {
+	if q.OwnerId != nil && lo.OwnerId != q.GetOwnerId() {
+		return false
+	}
+	if q.Name != nil && !strings.Contains(strings.ToLower(lo.Name), strings.ToLower(q.GetName())) {
+		return false
+	}
+	if q.Type != nil && lo.Type != q.GetType() {
+		return false
+	}
+	if q.Bbox != nil {
+		lon, lat := lo.Coordiantes.Longitude, lo.Coordiantes.Latitude
+		topLeft, bottomRight := q.Bbox.GetTopLeft(), q.Bbox.GetBottomRight()
+		if lon < topLeft.GetLongitude() || lon > bottomRight.GetLongitude() ||
+			lat < topLeft.GetLatitude() || lat > bottomRight.GetLatitude() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s memoryLeftoverStore) Search(ctx context.Context, q *leftover.LeftoverQuery) ([]*leftover.Leftover, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	items := make([]*leftover.Leftover, 0)
+	for _, id := range s.m.leftoverOrder {
+		if lo := s.m.leftovers[id]; matches(lo, q) {
+			items = append(items, proto.Clone(lo).(*leftover.Leftover))
+		}
+	}
+	return items, nil
+}
+
+func (s memoryLeftoverStore) Update(ctx context.Context, lo *leftover.Leftover) (string, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	current, ok := s.m.leftovers[lo.Id]
+	if !ok {
+		return "", leftover.ErrNotFound
+	}
+	updated := stored(lo)
+	updated.Type = current.Type
+	s.m.leftovers[lo.Id] = updated
+	return updated.Type, nil
+}
+
+func (s memoryLeftoverStore) Delete(ctx context.Context, id string, ownerID string) (*leftover.Leftover, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	lo, ok := s.m.leftovers[id]
+	if !ok || lo.OwnerId != ownerID {
+		return nil, leftover.ErrNotFound
+	}
+	delete(s.m.leftovers, id)
+	s.m.leftoverOrder = slices.DeleteFunc(s.m.leftoverOrder, func(other string) bool { return other == id })
+
+	return &leftover.Leftover{
+		Id:      id,
+		OwnerId: ownerID,
+		Type:    lo.Type,
+		Address: &leftover.Address{City: lo.Address.City},
+	}, nil
+}
@@ -0,0 +1,106 @@
+// Package storage holds the storage backends that are not tied to a single domain package.
+package storage
+
+import (
+	"encoding/json"
+	"lovco/server/chat"
+	"lovco/server/leftover"
+	"lovco/server/notification"
+	"lovco/server/webhook"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps everything the server stores in memory, so it runs without Postgres.
+// It is meant for local development and tests: nothing survives a restart and it cannot be
+// shared between replicas, so it only goes together with the memory pub/sub broker.
+//
+// The domains read each other's data like the Postgres stores join their tables,
+// e.g. a chat message queues a notification and a webhook event looks up its leftover,
+// so they share one lock and are handed out by Leftovers, Chats, Notifications and Webhooks.
+type MemoryStore struct {
+	mu sync.Mutex
+
+	leftovers     map[string]*leftover.Leftover
+	leftoverOrder []string
+
+	blocks     map[string][]blockedUser      // user id to the users they blocked, oldest first
+	bans       map[string]map[string]bool    // leftover id to the banned user ids
+	messages   map[string]*storedMessage     // message id to message
+	history    map[string][]*storedMessage   // leftover id to its messages, oldest first
+	agreements map[string]*chat.AgreedPickup // leftover id to its agreed pickup
+	rooms      map[string]chat.RoomSnapshot
+
+	preferences map[string]map[string]*notification.Preference // user id to kind to preference
+	searches    []*notification.SavedSearch                    // oldest first
+	outbox      []*outboxRow
+	outboxSeq   int64
+
+	webhooks    []*webhookRow
+	deliveries  []*deliveryRow
+	deliverySeq int64
+}
+
+type blockedUser struct {
+	id        string
+	createdAt time.Time
+}
+
+type storedMessage struct {
+	msg         *chat.ChatMessage
+	deliveredAt *time.Time
+	deletedAt   *time.Time
+}
+
+type outboxRow struct {
+	id            int64
+	userID        string
+	kind          string
+	subjectID     string
+	payload       json.RawMessage
+	attempts      int
+	lastError     string
+	createdAt     time.Time
+	nextAttemptAt time.Time
+	sentAt        *time.Time
+}
+
+type webhookRow struct {
+	hook      *webhook.Webhook
+	secret    string
+	deletedAt *time.Time
+}
+
+type deliveryRow struct {
+	delivery *webhook.Delivery
+	hook     *webhookRow
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		leftovers:   make(map[string]*leftover.Leftover),
+		blocks:      make(map[string][]blockedUser),
+		bans:        make(map[string]map[string]bool),
+		messages:    make(map[string]*storedMessage),
+		history:     make(map[string][]*storedMessage),
+		agreements:  make(map[string]*chat.AgreedPickup),
+		rooms:       make(map[string]chat.RoomSnapshot),
+		preferences: make(map[string]map[string]*notification.Preference),
+	}
+}
+
+func (m *MemoryStore) Leftovers() leftover.LeftoverStore {
+	return memoryLeftoverStore{m: m}
+}
+
+func (m *MemoryStore) Chats() chat.ChatStore {
+	return memoryChatStore{m: m}
+}
+
+func (m *MemoryStore) Notifications() notification.NotificationStore {
+	return memoryNotificationStore{m: m}
+}
+
+func (m *MemoryStore) Webhooks() webhook.WebhookStore {
+	return memoryWebhookStore{m: m}
+}
@@ -0,0 +1,235 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"lovco/server/leftover"
+	"lovco/server/notification"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type memoryNotificationStore struct {
+	m *MemoryStore
+}
+
+// enqueue adds an outbox row. m.mu must be held by the caller.
+func (m *MemoryStore) enqueue(userID string, kind string, subjectID string, payload json.RawMessage, at time.Time) {
+	m.outboxSeq++
+	m.outbox = append(m.outbox, &outboxRow{
+		id:            m.outboxSeq,
+		userID:        userID,
+		kind:          kind,
+		subjectID:     subjectID,
+		payload:       payload,
+		createdAt:     at,
+		nextAttemptAt: at,
+	})
+}
+
+func (s memoryNotificationStore) Preferences(ctx context.Context, userID string) ([]*notification.Preference, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	items := make([]*notification.Preference, 0, len(s.m.preferences[userID]))
+	for _, p := range s.m.preferences[userID] {
+		items = append(items, proto.Clone(p).(*notification.Preference))
+	}
+	slices.SortFunc(items, func(a, b *notification.Preference) int { return cmp.Compare(a.Kind, b.Kind) })
+	return items, nil
+}
+
+func (s memoryNotificationStore) UpsertPreference(ctx context.Context, userID string, p *notification.Preference) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if s.m.preferences[userID] == nil {
+		s.m.preferences[userID] = make(map[string]*notification.Preference)
+	}
+	s.m.preferences[userID][p.Kind] = proto.Clone(p).(*notification.Preference)
+	return nil
+}
+
+func (s memoryNotificationStore) SaveSearch(ctx context.Context, search *notification.SavedSearch, limit int) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	count := 0
+	for _, other := range s.m.searches {
+		if other.UserId == search.UserId {
+			count++
+		}
+	}
+	if count >= limit {
+		return false, nil
+	}
+	s.m.searches = append(s.m.searches, proto.Clone(search).(*notification.SavedSearch))
+	return true, nil
+}
+
+func (s memoryNotificationStore) SavedSearches(ctx context.Context, userID string) ([]*notification.SavedSearch, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	items := make([]*notification.SavedSearch, 0)
+	for _, search := range s.m.searches {
+		if search.UserId == userID {
+			items = append(items, proto.Clone(search).(*notification.SavedSearch))
+		}
+	}
+	return items, nil
+}
+
+func (s memoryNotificationStore) DeleteSavedSearch(ctx context.Context, id string, userID string) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	n := len(s.m.searches)
+	s.m.searches = slices.DeleteFunc(s.m.searches, func(search *notification.SavedSearch) bool {
+		return search.Id == id && search.UserId == userID
+	})
+	return len(s.m.searches) < n, nil
+}
+
+type searchNotification struct {
+	LeftoverID string `json:"leftover_id"`
+	SearchID   string `json:"search_id"`
+	Text       string `json:"text"`
+}
+
+// matchesSearch applies a saved search of another user the way the Postgres leftover store does.
+func matchesSearch(lo *leftover.Leftover, search *notification.SavedSearch) bool {
+	city := lo.GetAddress().GetCity()
+	return lo.OwnerId != search.UserId &&
+		(search.Name == nil || strings.Contains(strings.ToLower(lo.Name), strings.ToLower(search.GetName()))) &&
+		(search.Type == nil || lo.Type == search.GetType()) &&
+		(search.City == nil || strings.EqualFold(city, search.GetCity()))
+}
+
+// notifySearches queues a saved_search notification for every user with a search that matches lo,
+// one per user. m.mu must be held by the caller.
+func (m *MemoryStore) notifySearches(lo *leftover.Leftover, at time.Time) error {
+	notified := make(map[string]bool)
+	for _, search := range m.searches {
+		if notified[search.UserId] || !matchesSearch(lo, search) {
+			continue
+		}
+		payload, err := json.Marshal(searchNotification{
+			LeftoverID: lo.Id,
+			SearchID:   search.Id,
+			Text:       "New leftover " + lo.Name + " in " + lo.GetAddress().GetCity(),
+		})
+		if err != nil {
+			return err
+		}
+		m.enqueue(search.UserId, notification.KindSavedSearch, lo.Id, payload, at)
+		notified[search.UserId] = true
+	}
+	return nil
+}
+
+func (s memoryNotificationStore) Enqueue(ctx context.Context, userID string, kind string, subjectID string, payload json.RawMessage, at time.Time) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	s.m.enqueue(userID, kind, subjectID, payload, at)
+	return nil
+}
+
+// isStale tells whether the recipient already got a chat item on a live stream or saw it deleted,
+// or the leftover of a saved search hit is gone. m.mu must be held by the caller.
+func (m *MemoryStore) isStale(row *outboxRow) bool {
+	switch row.kind {
+	case notification.KindChatMessage:
+		sm, ok := m.messages[row.subjectID]
+		return ok && (sm.deliveredAt != nil || sm.deletedAt != nil)
+	case notification.KindSavedSearch:
+		_, ok := m.leftovers[row.subjectID]
+		return !ok
+	}
+	return false
+}
+
+func (s memoryNotificationStore) ClaimOutbox(ctx context.Context, createdBefore time.Time, now time.Time, leaseUntil time.Time, limit int) ([]notification.OutboxItem, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	rows := make([]*outboxRow, 0)
+	for _, row := range s.m.outbox {
+		if row.sentAt == nil && !row.createdAt.After(createdBefore) && !row.nextAttemptAt.After(now) {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b *outboxRow) int {
+		return cmp.Or(cmp.Compare(a.userID, b.userID), cmp.Compare(a.kind, b.kind), a.createdAt.Compare(b.createdAt))
+	})
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+
+	items := make([]notification.OutboxItem, 0, len(rows))
+	for _, row := range rows {
+		row.nextAttemptAt = leaseUntil
+		items = append(items, notification.OutboxItem{
+			ID:       row.id,
+			UserID:   row.userID,
+			Kind:     row.kind,
+			Payload:  row.payload,
+			Attempts: row.attempts,
+			Stale:    s.m.isStale(row),
+		})
+	}
+	return items, nil
+}
+
+// each applies mark to the unsent rows with one of ids.
+func (s memoryNotificationStore) each(ids []int64, mark func(row *outboxRow)) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	for _, row := range s.m.outbox {
+		if row.sentAt == nil && slices.Contains(ids, row.id) {
+			mark(row)
+		}
+	}
+}
+
+func (s memoryNotificationStore) Preference(ctx context.Context, userID string, kind string) (*notification.Preference, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	p, ok := s.m.preferences[userID][kind]
+	if !ok {
+		return nil, nil
+	}
+	return proto.Clone(p).(*notification.Preference), nil
+}
+
+func (s memoryNotificationStore) MarkSkipped(ctx context.Context, ids []int64, at time.Time) error {
+	s.each(ids, func(row *outboxRow) {
+		row.sentAt = &at
+	})
+	return nil
+}
+
+func (s memoryNotificationStore) MarkSent(ctx context.Context, ids []int64, at time.Time, note string) error {
+	s.each(ids, func(row *outboxRow) {
+		row.sentAt = &at
+		row.attempts++
+		row.lastError = note
+	})
+	return nil
+}
+
+func (s memoryNotificationStore) MarkFailed(ctx context.Context, ids []int64, lastError string, next time.Time) error {
+	s.each(ids, func(row *outboxRow) {
+		row.attempts++
+		row.lastError = lastError
+		row.nextAttemptAt = next
+	})
+	return nil
+}
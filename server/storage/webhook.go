@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"lovco/server/webhook"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type memoryWebhookStore struct {
+	m *MemoryStore
+}
+
+// wants tells whether a live webhook is subscribed to ev and its filter matches.
+func (row *webhookRow) wants(ev webhook.Event) bool {
+	if row.deletedAt != nil || !slices.Contains(row.hook.EventTypes, ev.Name) {
+		return false
+	}
+	filter := row.hook.Filter
+	if filter.Type != nil && filter.GetType() != ev.Type {
+		return false
+	}
+	if filter.City != nil && !strings.EqualFold(filter.GetCity(), ev.City) {
+		return false
+	}
+	return true
+}
+
+// emit queues a delivery of ev for every webhook that wants it, only those of ownerID unless it is empty.
+// m.mu must be held by the caller.
+func (m *MemoryStore) emit(ev webhook.Event, ownerID string) error {
+	payload, err := json.Marshal(ev.Data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, row := range m.webhooks {
+		if !row.wants(ev) || (ownerID != "" && row.hook.OwnerId != ownerID) {
+			continue
+		}
+		m.deliverySeq++
+		m.deliveries = append(m.deliveries, &deliveryRow{
+			hook: row,
+			delivery: &webhook.Delivery{
+				Id:            m.deliverySeq,
+				WebhookId:     row.hook.Id,
+				EventType:     ev.Name,
+				Payload:       string(payload),
+				Status:        webhook.DeliveryStatus_DELIVERY_STATUS_PENDING,
+				CreatedAt:     timestamppb.New(now),
+				NextAttemptAt: timestamppb.New(now),
+			},
+		})
+	}
+	return nil
+}
+
+func (s memoryWebhookStore) Emit(ctx context.Context, ev webhook.Event) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	return s.m.emit(ev, "")
+}
+
+func (s memoryWebhookStore) EmitForLeftover(ctx context.Context, name string, leftoverID string, data any) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	lo, ok := s.m.leftovers[leftoverID]
+	if !ok {
+		return nil
+	}
+	ownerID := ""
+	if webhook.OwnerOnly(name) {
+		ownerID = lo.OwnerId
+	}
+	return s.m.emit(webhook.Event{Name: name, Type: lo.Type, City: lo.Address.City, Data: data}, ownerID)
+}
+
+func (s memoryWebhookStore) Register(ctx context.Context, hook *webhook.Webhook, secret string) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	s.m.webhooks = append(s.m.webhooks, &webhookRow{
+		hook:   proto.Clone(hook).(*webhook.Webhook),
+		secret: secret,
+	})
+	return nil
+}
+
+func (s memoryWebhookStore) List(ctx context.Context, ownerID string) ([]*webhook.Webhook, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	items := make([]*webhook.Webhook, 0)
+	for _, row := range s.m.webhooks {
+		if row.hook.OwnerId == ownerID && row.deletedAt == nil {
+			items = append(items, proto.Clone(row.hook).(*webhook.Webhook))
+		}
+	}
+	return items, nil
+}
+
+// find returns the webhook with id, deleted or not. m.mu must be held by the caller.
+func (m *MemoryStore) find(id string) *webhookRow {
+	i := slices.IndexFunc(m.webhooks, func(row *webhookRow) bool { return row.hook.Id == id })
+	if i < 0 {
+		return nil
+	}
+	return m.webhooks[i]
+}
+
+func (s memoryWebhookStore) Delete(ctx context.Context, id string, ownerID string, at time.Time) (bool, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	row := s.m.find(id)
+	if row == nil || row.hook.OwnerId != ownerID || row.deletedAt != nil {
+		return false, nil
+	}
+	row.deletedAt = &at
+	return true, nil
+}
+
+func (s memoryWebhookStore) Owner(ctx context.Context, id string) (string, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	row := s.m.find(id)
+	if row == nil {
+		return "", webhook.ErrNotFound
+	}
+	return row.hook.OwnerId, nil
+}
+
+func (s memoryWebhookStore) ListDeliveries(ctx context.Context, webhookID string, state *webhook.DeliveryStatus, limit int32) ([]*webhook.Delivery, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	items := make([]*webhook.Delivery, 0)
+	// deliveries are appended as they are created, so walking backwards lists the newest first
+	for i := len(s.m.deliveries) - 1; i >= 0 && len(items) < int(limit); i-- {
+		d := s.m.deliveries[i].delivery
+		if d.WebhookId != webhookID || (state != nil && d.Status != *state) {
+			continue
+		}
+		items = append(items, proto.Clone(d).(*webhook.Delivery))
+	}
+	return items, nil
+}
+
+func (s memoryWebhookStore) ClaimDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]webhook.PendingDelivery, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	rows := make([]*deliveryRow, 0)
+	for _, row := range s.m.deliveries {
+		d := row.delivery
+		if d.Status == webhook.DeliveryStatus_DELIVERY_STATUS_PENDING && !d.NextAttemptAt.AsTime().After(now) {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b *deliveryRow) int {
+		return a.delivery.NextAttemptAt.AsTime().Compare(b.delivery.NextAttemptAt.AsTime())
+	})
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+
+	deliveries := make([]webhook.PendingDelivery, 0, len(rows))
+	for _, row := range rows {
+		row.delivery.NextAttemptAt = timestamppb.New(leaseUntil)
+		deliveries = append(deliveries, webhook.PendingDelivery{
+			ID:        row.delivery.Id,
+			EventType: row.delivery.EventType,
+			Payload:   json.RawMessage(row.delivery.Payload),
+			Attempts:  int(row.delivery.Attempts),
+			CreatedAt: row.delivery.CreatedAt.AsTime(),
+			URL:       row.hook.hook.Url,
+			Secret:    row.hook.secret,
+			Deleted:   row.hook.deletedAt != nil,
+		})
+	}
+	return deliveries, nil
+}
+
+// mark applies fn to the delivery with id if it is still pending.
+func (s memoryWebhookStore) mark(id int64, fn func(d *webhook.Delivery)) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	for _, row := range s.m.deliveries {
+		if row.delivery.Id == id && row.delivery.Status == webhook.DeliveryStatus_DELIVERY_STATUS_PENDING {
+			fn(row.delivery)
+		}
+	}
+}
+
+func (s memoryWebhookStore) MarkDelivered(ctx context.Context, id int64, statusCode int, at time.Time) error {
+	s.mark(id, func(d *webhook.Delivery) {
+		d.Status = webhook.DeliveryStatus_DELIVERY_STATUS_DELIVERED
+		d.Attempts++
+		d.LastStatusCode = int32(statusCode)
+		d.LastError = ""
+		d.DeliveredAt = timestamppb.New(at)
+	})
+	return nil
+}
+
+func (s memoryWebhookStore) MarkRetry(ctx context.Context, id int64, statusCode int, lastError string, next time.Time) error {
+	s.mark(id, func(d *webhook.Delivery) {
+		d.Attempts++
+		d.LastStatusCode = int32(statusCode)
+		d.LastError = lastError
+		d.NextAttemptAt = timestamppb.New(next)
+	})
+	return nil
+}
+
+func (s memoryWebhookStore) MarkDead(ctx context.Context, id int64, attempted bool, statusCode int, lastError string) error {
+	s.mark(id, func(d *webhook.Delivery) {
+		d.Status = webhook.DeliveryStatus_DELIVERY_STATUS_DEAD
+		if attempted {
+			d.Attempts++
+		}
+		d.LastStatusCode = int32(statusCode)
+		d.LastError = lastError
+	})
+	return nil
+}
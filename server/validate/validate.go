@@ -0,0 +1,288 @@
+// Package validate checks requests against rules declared per message before they reach a handler.
+// A request breaking a rule is refused with InvalidArgument and a google.rpc.BadRequest detail
+// naming every violated field by its proto path, e.g. coordinates.latitude or items[2].channel,
+// so a client can show the problem next to the form field it came from.
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Reasons of the field violations, clients can map them to messages of their own.
+const (
+	ReasonRequired      = "REQUIRED"
+	ReasonTooLong       = "TOO_LONG"
+	ReasonNotAllowed    = "NOT_ALLOWED"
+	ReasonOutOfRange    = "OUT_OF_RANGE"
+	ReasonInvalidFormat = "INVALID_FORMAT"
+	ReasonInvalidRange  = "INVALID_RANGE"
+	ReasonConflict      = "CONFLICT"
+)
+
+// Violations collects the fields a request got wrong.
+type Violations []*errdetails.BadRequest_FieldViolation
+
+// Add records that field broke a rule for reason, description is meant for humans.
+func (v *Violations) Add(field string, reason string, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Reason:      reason,
+		Description: description,
+	})
+}
+
+// Rule is one check of a message, made by Required, MaxLen, OneOf, Between, UUID, URL or Check.
+type Rule struct {
+	// path of the field the rule checks, empty for Check
+	path string
+	// kind of the fields the rule works on, any kind when empty
+	kind  string
+	kinds []protoreflect.Kind
+	check func(m protoreflect.Message, v *Violations)
+}
+
+// Rules maps the full name of a message to the rules its requests must follow.
+type Rules map[protoreflect.FullName][]Rule
+
+// Add declares rules for the requests of the type of msg. It panics when a rule names a field
+// the message does not have or of the wrong kind, so a typo fails at startup instead of letting requests through.
+func (r Rules) Add(msg proto.Message, rules ...Rule) {
+	desc := msg.ProtoReflect().Descriptor()
+	for _, rule := range rules {
+		if rule.path == "" {
+			continue
+		}
+		fd := mustResolve(desc, rule.path)
+		if len(rule.kinds) > 0 && !slices.Contains(rule.kinds, fd.Kind()) {
+			panic(fmt.Sprintf("validate: field %s of %s is not a %s", rule.path, desc.FullName(), rule.kind))
+		}
+	}
+	r[desc.FullName()] = append(r[desc.FullName()], rules...)
+}
+
+// Check runs the rules of req and returns an InvalidArgument error listing every violation,
+// nil when there is none or req is not a message with rules.
+func (r Rules) Check(req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	m := msg.ProtoReflect()
+	rules := r[m.Descriptor().FullName()]
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var v Violations
+	for _, rule := range rules {
+		rule.check(m, &v)
+	}
+	if len(v) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(v))
+	for _, fv := range v {
+		fields = append(fields, fv.Field)
+	}
+	st := status.New(codes.InvalidArgument, "invalid "+strings.Join(slices.Compact(fields), ", "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// Required reports the field at path when it is not set: an empty string, a zero number,
+// a missing message or an empty list. Fields in a message that is itself missing are not
+// checked, so Required("bbox.top_left") only requires a corner when there is a bbox.
+func Required(path string) Rule {
+	return Rule{path: path, check: func(m protoreflect.Message, v *Violations) {
+		walk(m, "", strings.Split(path, "."), func(m protoreflect.Message, fd protoreflect.FieldDescriptor, field string) {
+			if !m.Has(fd) {
+				v.Add(field, ReasonRequired, "is required")
+			}
+		})
+	}}
+}
+
+// MaxLen reports strings at path longer than n characters.
+func MaxLen(path string, n int) Rule {
+	return stringValues(path, func(s string, field string, v *Violations) {
+		if utf8.RuneCountInString(s) > n {
+			v.Add(field, ReasonTooLong, fmt.Sprintf("must be at most %d characters", n))
+		}
+	})
+}
+
+// OneOf reports strings at path that are not one of allowed.
+func OneOf(path string, allowed ...string) Rule {
+	return stringValues(path, func(s string, field string, v *Violations) {
+		if !slices.Contains(allowed, s) {
+			v.Add(field, ReasonNotAllowed, "must be one of "+strings.Join(allowed, ", "))
+		}
+	})
+}
+
+// UUID reports strings at path that are not a UUID.
+func UUID(path string) Rule {
+	return stringValues(path, func(s string, field string, v *Violations) {
+		if _, err := uuid.Parse(s); err != nil {
+			v.Add(field, ReasonInvalidFormat, "must be a UUID")
+		}
+	})
+}
+
+// ID is Required and UUID in one, for the ids every table keys on.
+func ID(path string) Rule {
+	required, rule := Required(path), UUID(path)
+	format := rule.check
+	rule.check = func(m protoreflect.Message, v *Violations) {
+		// UUID skips unset fields, so a field is never reported twice
+		required.check(m, v)
+		format(m, v)
+	}
+	return rule
+}
+
+// URL reports strings at path that are not an absolute http or https URL.
+func URL(path string) Rule {
+	return stringValues(path, func(s string, field string, v *Violations) {
+		if !IsHTTPURL(s) {
+			v.Add(field, ReasonInvalidFormat, "must be an absolute http or https URL")
+		}
+	})
+}
+
+// IsHTTPURL tells if s is an absolute http or https URL, for checks of URLs in a Check.
+func IsHTTPURL(s string) bool {
+	target, err := url.Parse(s)
+	return err == nil && (target.Scheme == "http" || target.Scheme == "https") && target.Host != ""
+}
+
+// Between reports numbers at path outside of [min, max].
+func Between(path string, min float64, max float64) Rule {
+	rule := values(path, func(value protoreflect.Value, field string, v *Violations) {
+		var n float64
+		switch x := value.Interface().(type) {
+		case float64:
+			n = x
+		case float32:
+			n = float64(x)
+		case int32:
+			n = float64(x)
+		case int64:
+			n = float64(x)
+		case uint32:
+			n = float64(x)
+		case uint64:
+			n = float64(x)
+		}
+		if n < min || n > max {
+			v.Add(field, ReasonOutOfRange, fmt.Sprintf("must be between %g and %g", min, max))
+		}
+	})
+	rule.kind = "number"
+	rule.kinds = []protoreflect.Kind{
+		protoreflect.DoubleKind, protoreflect.FloatKind,
+		protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind,
+	}
+	return rule
+}
+
+// Check runs fn on requests of type M, for rules spanning several fields like the order of two dates.
+func Check[M proto.Message](fn func(m M, v *Violations)) Rule {
+	return Rule{check: func(m protoreflect.Message, v *Violations) {
+		msg, ok := m.Interface().(M)
+		if !ok {
+			panic(fmt.Sprintf("validate: Check of %T added to %s", msg, m.Descriptor().FullName()))
+		}
+		fn(msg, v)
+	}}
+}
+
+// values runs fn on every set value of the field at path, the elements of a list one by one.
+// Unset fields are left to Required.
+func values(path string, fn func(value protoreflect.Value, field string, v *Violations)) Rule {
+	return Rule{path: path, check: func(m protoreflect.Message, v *Violations) {
+		walk(m, "", strings.Split(path, "."), func(m protoreflect.Message, fd protoreflect.FieldDescriptor, field string) {
+			if !m.Has(fd) {
+				return
+			}
+			if fd.IsList() {
+				list := m.Get(fd).List()
+				for i := range list.Len() {
+					fn(list.Get(i), fmt.Sprintf("%s[%d]", field, i), v)
+				}
+				return
+			}
+			fn(m.Get(fd), field, v)
+		})
+	}}
+}
+
+// stringValues is values for string fields.
+func stringValues(path string, fn func(s string, field string, v *Violations)) Rule {
+	rule := values(path, func(value protoreflect.Value, field string, v *Violations) {
+		fn(value.String(), field, v)
+	})
+	rule.kind = "string"
+	rule.kinds = []protoreflect.Kind{protoreflect.StringKind}
+	return rule
+}
+
+// walk calls fn with the message holding the last field of names and the path of that field,
+// e.g. items[2].kind. Missing messages on the way are skipped, lists of messages are walked element by element.
+func walk(m protoreflect.Message, prefix string, names []string, fn func(m protoreflect.Message, fd protoreflect.FieldDescriptor, field string)) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(names[0]))
+	field := prefix + names[0]
+	if len(names) == 1 {
+		fn(m, fd, field)
+		return
+	}
+
+	if !m.Has(fd) {
+		return
+	}
+	if fd.IsList() {
+		list := m.Get(fd).List()
+		for i := range list.Len() {
+			walk(list.Get(i).Message(), fmt.Sprintf("%s[%d].", field, i), names[1:], fn)
+		}
+		return
+	}
+	walk(m.Get(fd).Message(), field+".", names[1:], fn)
+}
+
+// mustResolve returns the field path leads to through the messages of desc and panics when there is none.
+func mustResolve(desc protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := desc.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			panic(fmt.Sprintf("validate: %s has no field %s", desc.FullName(), name))
+		}
+		if i == len(names)-1 {
+			return fd
+		}
+		if fd.Message() == nil || fd.IsMap() {
+			panic(fmt.Sprintf("validate: field %s of %s is not a message", name, desc.FullName()))
+		}
+		desc = fd.Message()
+	}
+	panic("unreachable")
+}
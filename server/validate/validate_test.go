@@ -0,0 +1,146 @@
+package validate
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// violations runs rules on msg and returns the violated fields with their reasons, e.g. "name TOO_LONG".
+func violations(t *testing.T, msg proto.Message, rules ...Rule) []string {
+	t.Helper()
+	r := Rules{}
+	r.Add(msg, rules...)
+
+	err := r.Check(msg)
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %s, want InvalidArgument", st.Code())
+	}
+	var got []string
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			for _, fv := range br.FieldViolations {
+				got = append(got, fv.Field+" "+fv.Reason)
+			}
+		}
+	}
+	return got
+}
+
+func TestRules(t *testing.T) {
+	const id = "0b8f4c4e-2d44-4b8e-9a43-5d2c1f1a0e6b"
+	list := func(values ...string) *structpb.Value {
+		items := make([]*structpb.Value, len(values))
+		for i, v := range values {
+			items[i] = structpb.NewStringValue(v)
+		}
+		return structpb.NewListValue(&structpb.ListValue{Values: items})
+	}
+
+	tests := []struct {
+		name string
+		msg  proto.Message
+		rule Rule
+		want []string
+	}{
+		{"required set", structpb.NewStringValue("x"), Required("string_value"), nil},
+		{"required missing", structpb.NewNumberValue(1), Required("string_value"), []string{"string_value REQUIRED"}},
+		{"required zero number", &timestamppb.Timestamp{}, Required("seconds"), []string{"seconds REQUIRED"}},
+		{"required in missing message", structpb.NewNullValue(), Required("list_value.values"), nil},
+		{"required empty list", list(), Required("list_value.values"), []string{"list_value.values REQUIRED"}},
+
+		{"max len fits", structpb.NewStringValue("äöü"), MaxLen("string_value", 3), nil},
+		{"max len too long", structpb.NewStringValue("abcd"), MaxLen("string_value", 3), []string{"string_value TOO_LONG"}},
+		{"max len unset", structpb.NewNullValue(), MaxLen("string_value", 3), nil},
+		{"max len list elements", list("ab", "abcd", "abcde"), MaxLen("list_value.values.string_value", 3),
+			[]string{"list_value.values[1].string_value TOO_LONG", "list_value.values[2].string_value TOO_LONG"}},
+
+		{"one of allowed", structpb.NewStringValue("food"), OneOf("string_value", "food", "other"), nil},
+		{"one of not allowed", structpb.NewStringValue("cars"), OneOf("string_value", "food", "other"), []string{"string_value NOT_ALLOWED"}},
+
+		{"uuid valid", structpb.NewStringValue(id), UUID("string_value"), nil},
+		{"uuid invalid", structpb.NewStringValue("42"), UUID("string_value"), []string{"string_value INVALID_FORMAT"}},
+		{"uuid unset", structpb.NewNullValue(), UUID("string_value"), nil},
+
+		{"id valid", structpb.NewStringValue(id), ID("string_value"), nil},
+		{"id missing", structpb.NewNullValue(), ID("string_value"), []string{"string_value REQUIRED"}},
+		{"id invalid", structpb.NewStringValue("42"), ID("string_value"), []string{"string_value INVALID_FORMAT"}},
+
+		{"url https", structpb.NewStringValue("https://example.com/hook"), URL("string_value"), nil},
+		{"url relative", structpb.NewStringValue("/hook"), URL("string_value"), []string{"string_value INVALID_FORMAT"}},
+		{"url other scheme", structpb.NewStringValue("ftp://example.com"), URL("string_value"), []string{"string_value INVALID_FORMAT"}},
+
+		{"between inside", structpb.NewNumberValue(90), Between("number_value", -90, 90), nil},
+		{"between below", structpb.NewNumberValue(-90.5), Between("number_value", -90, 90), []string{"number_value OUT_OF_RANGE"}},
+		{"between above", structpb.NewNumberValue(180), Between("number_value", -90, 90), []string{"number_value OUT_OF_RANGE"}},
+		{"between int", &timestamppb.Timestamp{Seconds: 61}, Between("seconds", 0, 60), []string{"seconds OUT_OF_RANGE"}},
+
+		{"check", structpb.NewStringValue("a"), Check(func(v *structpb.Value, vs *Violations) {
+			if v.GetStringValue() == "a" {
+				vs.Add("string_value", ReasonConflict, "is a")
+			}
+		}), []string{"string_value CONFLICT"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violations(t, tt.msg, tt.rule)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckListsEveryViolation(t *testing.T) {
+	r := Rules{}
+	r.Add(&timestamppb.Timestamp{}, Between("seconds", 0, 10), Between("nanos", 0, 10))
+
+	err := r.Check(&timestamppb.Timestamp{Seconds: 11, Nanos: 11})
+	if got, want := status.Convert(err).Message(), "invalid seconds, nanos"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+}
+
+func TestCheckWithoutRules(t *testing.T) {
+	r := Rules{}
+	r.Add(&timestamppb.Timestamp{}, Between("seconds", 0, 10))
+
+	if err := r.Check(structpb.NewStringValue("")); err != nil {
+		t.Errorf("message without rules: %v", err)
+	}
+	if err := r.Check("not a message"); err != nil {
+		t.Errorf("not a message: %v", err)
+	}
+}
+
+func TestAddPanicsOnBadRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"unknown field", Required("no_such_field")},
+		{"through a scalar", Required("string_value.x")},
+		{"string rule on number", MaxLen("number_value", 3)},
+		{"number rule on string", Between("string_value", 0, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Add did not panic")
+				}
+			}()
+			Rules{}.Add(&structpb.Value{}, tt.rule)
+		})
+	}
+}
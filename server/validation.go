@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"lovco/server/chat"
+	"lovco/server/leftover"
+	"lovco/server/notification"
+	"lovco/server/validate"
+	"lovco/server/webhook"
+
+	"google.golang.org/grpc"
+)
+
+// validationRules are the rules of the requests of every service.
+func validationRules() validate.Rules {
+	rules := validate.Rules{}
+	leftover.AddRules(rules)
+	chat.AddRules(rules)
+	notification.AddRules(rules)
+	webhook.AddRules(rules, leftover.Types)
+	return rules
+}
+
+// validatedStream checks every message the client sends on a stream.
+type validatedStream struct {
+	grpc.ServerStream
+	rules validate.Rules
+}
+
+func (s *validatedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.rules.Check(m)
+}
+
+// validationUnaryInterceptor refuses requests breaking the rules of their message before the handler runs.
+func validationUnaryInterceptor(rules validate.Rules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := rules.Check(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// validationStreamInterceptor is validationUnaryInterceptor for streams. The request of a server
+// stream is read before the handler runs, so a broken one fails the call before the handler starts.
+func validationStreamInterceptor(rules validate.Rules) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatedStream{ServerStream: ss, rules: rules})
+	}
+}
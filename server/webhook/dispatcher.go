@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"lovco/server/safehttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every delivery. The signature is "t=<unix time>,v1=<hex HMAC-SHA256>"
+// computed with the webhook secret over "<unix time>.<body>", see Sign.
+const (
+	HeaderEvent     = "X-Lovco-Event"
+	HeaderDelivery  = "X-Lovco-Delivery"
+	HeaderSignature = "X-Lovco-Signature"
+)
+
+// DispatcherOptions tunes the dispatcher.
+// Interval is how often pending deliveries are polled and the base of the retry backoff,
+// BatchSize how many deliveries one poll claims, MaxAttempts after how many failures a delivery is dead
+// and Timeout how long a single request may take. Webhooks on loopback, private and other internal
+// addresses are refused unless AllowPrivateTargets is set.
+type DispatcherOptions struct {
+	Interval            time.Duration
+	BatchSize           int
+	MaxAttempts         int
+	Timeout             time.Duration
+	AllowPrivateTargets bool
+}
+
+// Dispatcher posts the queued deliveries to the webhooks.
+// Several replicas can run it at the same time, a claim leases the deliveries to one of them
+// for as long as posting the whole batch may take. Nothing is held open while posting,
+// each outcome is recorded on its own once the webhook answered.
+type Dispatcher struct {
+	store  WebhookStore
+	client *http.Client
+	opts   DispatcherOptions
+	logger *slog.Logger
+}
+
+func NewDispatcher(store WebhookStore, opts DispatcherOptions, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		client: safehttp.NewClient(opts.Timeout, opts.AllowPrivateTargets),
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+// envelope is the body posted to the webhook.
+type envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sign returns the value of the signature header for body sent at t.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run polls the pending deliveries until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.dispatch(ctx); err != nil && ctx.Err() == nil {
+				d.logger.Error("failed to dispatch webhooks", "error", err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) error {
+	now := time.Now().UTC()
+	// the deliveries are posted one after the other, the lease covers a batch of timeouts
+	lease := time.Duration(d.opts.BatchSize)*d.opts.Timeout + d.opts.Interval
+	deliveries, err := d.store.ClaimDeliveries(ctx, now, now.Add(lease), d.opts.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, dl := range deliveries {
+		if err := d.deliver(ctx, dl); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			// the delivery is due again when its lease ends
+			d.logger.Error("failed to record webhook delivery", "delivery_id", dl.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// deliver posts one delivery and records the outcome. Only database errors are returned,
+// a failing webhook is retried on a later run.
+func (d *Dispatcher) deliver(ctx context.Context, dl PendingDelivery) error {
+	if dl.Deleted {
+		return d.store.MarkDead(ctx, dl.ID, false, 0, "webhook was deleted")
+	}
+
+	code, postErr := d.post(ctx, dl)
+	now := time.Now().UTC()
+	if postErr == nil {
+		return d.store.MarkDelivered(ctx, dl.ID, code, now)
+	}
+
+	attempts := dl.Attempts + 1
+	if attempts >= d.opts.MaxAttempts {
+		d.logger.Warn("webhook delivery is dead", "delivery_id", dl.ID, "event", dl.EventType, "attempts", attempts, "error", postErr)
+		return d.store.MarkDead(ctx, dl.ID, true, code, postErr.Error())
+	}
+
+	d.logger.Info("webhook delivery failed, retrying", "delivery_id", dl.ID, "event", dl.EventType, "attempts", attempts, "error", postErr)
+	backoff := d.opts.Interval << min(attempts, 12)
+	return d.store.MarkRetry(ctx, dl.ID, code, postErr.Error(), now.Add(backoff))
+}
+
+// post sends the delivery and returns the status code of the answer, 0 if there was none.
+func (d *Dispatcher) post(ctx context.Context, dl PendingDelivery) (int, error) {
+	body, err := json.Marshal(envelope{
+		ID:        dl.ID,
+		Type:      dl.EventType,
+		CreatedAt: dl.CreatedAt,
+		Data:      dl.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, dl.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(dl.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(dl.Secret, time.Now(), body))
+
+	resp, err := d.client.Do(req)
+	if errors.Is(err, safehttp.ErrNotAllowed) {
+		// the owner sees the error of the delivery, it does not tell what the name resolved to
+		return 0, safehttp.ErrNotAllowed
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook answered with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
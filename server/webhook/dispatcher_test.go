@@ -0,0 +1,177 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"lovco/server/storage"
+	"lovco/server/webhook"
+)
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"id":1}`)
+
+	known := webhook.Sign("whsec_test", at, body)
+	if want := "t=1700000000,v1=2f441ba4b3b2d50d28a9ab9d9fd8880376ecd1eb5d0435401553f5d8d0a5dcf8"; known != want {
+		t.Fatalf("Sign = %q, want %q", known, want)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		at     time.Time
+		body   []byte
+	}{
+		{"time", "whsec_test", at.Add(time.Second), body},
+		{"body", "whsec_test", at, []byte(`{"id":2}`)},
+		{"secret", "other", at, body},
+	}
+	_, knownMAC, _ := strings.Cut(known, ",v1=")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := webhook.Sign(tt.secret, tt.at, tt.body)
+			if _, mac, _ := strings.Cut(got, ",v1="); mac == knownMAC {
+				t.Errorf("another %s gives the same signature %q", tt.name, got)
+			}
+		})
+	}
+}
+
+// verify checks a signature header the way a receiver is told to.
+func verify(header string, secret string, body []byte) bool {
+	ts, sig, ok := strings.Cut(header, ",v1=")
+	ts, found := strings.CutPrefix(ts, "t=")
+	if !ok || !found {
+		return false
+	}
+	if _, err := strconv.ParseInt(ts, 10, 64); err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	want, err := hex.DecodeString(sig)
+	return err == nil && hmac.Equal(mac.Sum(nil), want)
+}
+
+func TestDispatcherSignsDeliveries(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	got := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- received{r.Header, body}
+	}))
+	defer srv.Close()
+
+	store := storage.NewMemoryStore().Webhooks()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hook := &webhook.Webhook{
+		Id:         "9a1d8f7e-35a7-4d5e-9b53-0d0c8c9b2c11",
+		OwnerId:    "2f7b6a55-0d7c-4f61-8d0e-6f3b1b1f5a42",
+		Url:        srv.URL,
+		EventTypes: []string{webhook.EventLeftoverCreated},
+		Filter:     &webhook.WebhookFilter{},
+	}
+	if err := store.Register(ctx, hook, "whsec_test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Emit(ctx, webhook.Event{Name: webhook.EventLeftoverCreated, Type: "food", City: "Vienna", Data: map[string]string{"name": "Bread"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := webhook.NewDispatcher(store, webhook.DispatcherOptions{
+		Interval:    10 * time.Millisecond,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		Timeout:     time.Second,
+		// the test server listens on loopback
+		AllowPrivateTargets: true,
+	}, slog.New(slog.DiscardHandler))
+	go d.Run(ctx)
+
+	select {
+	case r := <-got:
+		if ev := r.header.Get(webhook.HeaderEvent); ev != webhook.EventLeftoverCreated {
+			t.Errorf("event header = %q", ev)
+		}
+		if !verify(r.header.Get(webhook.HeaderSignature), "whsec_test", r.body) {
+			t.Errorf("signature %q does not verify for %s", r.header.Get(webhook.HeaderSignature), r.body)
+		}
+		if verify(r.header.Get(webhook.HeaderSignature), "other", r.body) {
+			t.Error("signature verifies with another secret")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no delivery was posted")
+	}
+}
+
+func TestDispatcherRefusesLoopback(t *testing.T) {
+	posted := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted <- struct{}{}
+	}))
+	defer srv.Close()
+
+	store := storage.NewMemoryStore().Webhooks()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hook := &webhook.Webhook{
+		Id:         "9a1d8f7e-35a7-4d5e-9b53-0d0c8c9b2c11",
+		OwnerId:    "2f7b6a55-0d7c-4f61-8d0e-6f3b1b1f5a42",
+		Url:        srv.URL,
+		EventTypes: []string{webhook.EventLeftoverCreated},
+		Filter:     &webhook.WebhookFilter{},
+	}
+	if err := store.Register(ctx, hook, "whsec_test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Emit(ctx, webhook.Event{Name: webhook.EventLeftoverCreated, Data: struct{}{}}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := webhook.NewDispatcher(store, webhook.DispatcherOptions{
+		Interval:    10 * time.Millisecond,
+		BatchSize:   10,
+		MaxAttempts: 1,
+		Timeout:     time.Second,
+	}, slog.New(slog.DiscardHandler))
+	go d.Run(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		deliveries, err := store.ListDeliveries(ctx, hook.Id, nil, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deliveries) == 1 && deliveries[0].Status == webhook.DeliveryStatus_DELIVERY_STATUS_DEAD {
+			if deliveries[0].LastError != "destination address is not allowed" {
+				t.Errorf("last error = %q", deliveries[0].LastError)
+			}
+			select {
+			case <-posted:
+				t.Error("the delivery reached the loopback server")
+			default:
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("the delivery was not given up")
+}
@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// emitQuery queues a delivery for every webhook subscribed to the event whose filter matches
+	emitQuery = `
+		INSERT INTO webhook_delivery (webhook_id, event_type, payload, created_at, next_attempt_at)
+		SELECT id, $1, $2, $5, $5
+		FROM webhook
+		WHERE deleted_at IS NULL AND $1 = ANY(event_types)
+			AND (filter_type IS NULL OR filter_type = $3)
+			AND (filter_city IS NULL OR lower(filter_city) = lower($4));
+	`
+	// emitForLeftoverQuery is emitQuery for events that only know the leftover id,
+	// $5 limits the deliveries to the webhooks of the leftover owner
+	emitForLeftoverQuery = `
+		INSERT INTO webhook_delivery (webhook_id, event_type, payload, created_at, next_attempt_at)
+		SELECT w.id, $1, $2, $4, $4
+		FROM webhook w
+		JOIN leftover l ON l.id = $3
+		WHERE w.deleted_at IS NULL AND $1 = ANY(w.event_types)
+			AND (NOT $5 OR w.owner_id = l.owner_id)
+			AND (w.filter_type IS NULL OR w.filter_type = l.type::text)
+			AND (w.filter_city IS NULL OR lower(w.filter_city) = lower(l.city));
+	`
+	registerWebhookQuery = `
+		INSERT INTO webhook (id, owner_id, url, secret, event_types, filter_type, filter_city, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+	`
+	listWebhooksQuery = `
+		SELECT id, owner_id, url, event_types, filter_type, filter_city, created_at
+		FROM webhook
+		WHERE owner_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at;
+	`
+	// deleted webhooks are kept so their deliveries can still be inspected
+	deleteWebhookQuery = `
+		UPDATE webhook
+		SET deleted_at = $1
+		WHERE id = $2 AND owner_id = $3 AND deleted_at IS NULL;
+	`
+	webhookOwnerQuery = `
+		SELECT owner_id
+		FROM webhook
+		WHERE id = $1;
+	`
+	listDeliveriesQuery = `
+		SELECT id, webhook_id, event_type, payload::text, status, attempts, COALESCE(last_status_code, 0), COALESCE(last_error, ''),
+			created_at, next_attempt_at, delivered_at
+		FROM webhook_delivery
+		WHERE webhook_id = $1 AND ($2::text IS NULL OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3;
+	`
+	// claimDeliveriesQuery leases the due deliveries by moving their next attempt to the end of the lease,
+	// the rows are only locked for the statement
+	claimDeliveriesQuery = `
+		WITH due AS (
+			SELECT id
+			FROM webhook_delivery
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_delivery d
+		SET next_attempt_at = $2
+		FROM due, webhook w
+		WHERE d.id = due.id AND w.id = d.webhook_id
+		RETURNING d.id, d.event_type, d.payload, d.attempts, d.created_at, w.url, w.secret, w.deleted_at IS NOT NULL;
+	`
+	markDeliveredQuery = `
+		UPDATE webhook_delivery
+		SET status = 'delivered', attempts = attempts + 1, last_status_code = $1, last_error = NULL, delivered_at = $2
+		WHERE id = $3 AND status = 'pending';
+	`
+	markRetryQuery = `
+		UPDATE webhook_delivery
+		SET attempts = attempts + 1, last_status_code = $1, last_error = $2, next_attempt_at = $3
+		WHERE id = $4 AND status = 'pending';
+	`
+	markDeadQuery = `
+		UPDATE webhook_delivery
+		SET status = 'dead', attempts = attempts + $1, last_status_code = $2, last_error = $3
+		WHERE id = $4 AND status = 'pending';
+	`
+)
+
+type DatabaseInterface interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// PostgresWebhookStore keeps the webhooks and their deliveries in the webhook and webhook_delivery tables.
+type PostgresWebhookStore struct {
+	db DatabaseInterface
+}
+
+func NewPostgresWebhookStore(db DatabaseInterface) *PostgresWebhookStore {
+	return &PostgresWebhookStore{
+		db: db,
+	}
+}
+
+func (s *PostgresWebhookStore) Emit(ctx context.Context, ev Event) error {
+	payload, err := json.Marshal(ev.Data)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(ctx, emitQuery, ev.Name, payload, ev.Type, ev.City, time.Now().UTC())
+	return err
+}
+
+func (s *PostgresWebhookStore) EmitForLeftover(ctx context.Context, name string, leftoverID string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(ctx, emitForLeftoverQuery, name, payload, leftoverID, time.Now().UTC(), OwnerOnly(name))
+	return err
+}
+
+func (s *PostgresWebhookStore) Register(ctx context.Context, hook *Webhook, secret string) error {
+	_, err := s.db.Exec(ctx, registerWebhookQuery, hook.Id, hook.OwnerId, hook.Url, secret, hook.EventTypes,
+		hook.Filter.Type, hook.Filter.City, hook.CreatedAt.AsTime())
+	return err
+}
+
+func (s *PostgresWebhookStore) List(ctx context.Context, ownerID string) ([]*Webhook, error) {
+	rows, err := s.db.Query(ctx, listWebhooksQuery, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*Webhook, 0)
+	for rows.Next() {
+		var (
+			hook      Webhook
+			filter    WebhookFilter
+			createdAt time.Time
+		)
+		if err := rows.Scan(&hook.Id, &hook.OwnerId, &hook.Url, &hook.EventTypes, &filter.Type, &filter.City, &createdAt); err != nil {
+			return nil, err
+		}
+		hook.Filter = &filter
+		hook.CreatedAt = timestamppb.New(createdAt)
+		items = append(items, &hook)
+	}
+	return items, rows.Err()
+}
+
+func (s *PostgresWebhookStore) Delete(ctx context.Context, id string, ownerID string, at time.Time) (bool, error) {
+	tag, err := s.db.Exec(ctx, deleteWebhookQuery, at, id, ownerID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *PostgresWebhookStore) Owner(ctx context.Context, id string) (string, error) {
+	var ownerID string
+	err := s.db.QueryRow(ctx, webhookOwnerQuery, id).Scan(&ownerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return ownerID, err
+}
+
+func (s *PostgresWebhookStore) ListDeliveries(ctx context.Context, webhookID string, state *DeliveryStatus, limit int32) ([]*Delivery, error) {
+	var statusFilter any
+	if state != nil {
+		statusFilter = deliveryStatus(*state)
+	}
+
+	rows, err := s.db.Query(ctx, listDeliveriesQuery, webhookID, statusFilter, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*Delivery, 0)
+	for rows.Next() {
+		var (
+			d                      Delivery
+			state                  string
+			createdAt, nextAttempt time.Time
+			deliveredAt            *time.Time
+		)
+		err := rows.Scan(&d.Id, &d.WebhookId, &d.EventType, &d.Payload, &state, &d.Attempts, &d.LastStatusCode, &d.LastError,
+			&createdAt, &nextAttempt, &deliveredAt)
+		if err != nil {
+			return nil, err
+		}
+		d.Status = protoStatus(state)
+		d.CreatedAt = timestamppb.New(createdAt)
+		d.NextAttemptAt = timestamppb.New(nextAttempt)
+		if deliveredAt != nil {
+			d.DeliveredAt = timestamppb.New(*deliveredAt)
+		}
+		items = append(items, &d)
+	}
+	return items, rows.Err()
+}
+
+func (s *PostgresWebhookStore) ClaimDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]PendingDelivery, error) {
+	rows, err := s.db.Query(ctx, claimDeliveriesQuery, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]PendingDelivery, 0)
+	for rows.Next() {
+		var dl PendingDelivery
+		if err := rows.Scan(&dl.ID, &dl.EventType, &dl.Payload, &dl.Attempts, &dl.CreatedAt, &dl.URL, &dl.Secret, &dl.Deleted); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, dl)
+	}
+	return deliveries, rows.Err()
+}
+
+// nullableCode stores a missing status code as NULL.
+func nullableCode(code int) any {
+	if code == 0 {
+		return nil
+	}
+	return code
+}
+
+func (s *PostgresWebhookStore) MarkDelivered(ctx context.Context, id int64, statusCode int, at time.Time) error {
+	_, err := s.db.Exec(ctx, markDeliveredQuery, statusCode, at, id)
+	return err
+}
+
+func (s *PostgresWebhookStore) MarkRetry(ctx context.Context, id int64, statusCode int, lastError string, next time.Time) error {
+	_, err := s.db.Exec(ctx, markRetryQuery, nullableCode(statusCode), lastError, next, id)
+	return err
+}
+
+func (s *PostgresWebhookStore) MarkDead(ctx context.Context, id int64, attempted bool, statusCode int, lastError string) error {
+	attempts := 0
+	if attempted {
+		attempts = 1
+	}
+	_, err := s.db.Exec(ctx, markDeadQuery, attempts, nullableCode(statusCode), lastError, id)
+	return err
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"lovco/server/validate"
+)
+
+// maxDeliveriesLimit is the most deliveries ListDeliveries returns at once.
+const maxDeliveriesLimit = 1000
+
+// AddRules declares the rules the requests of the webhook service must follow.
+// leftoverTypes are passed in because the leftover package imports this one.
+func AddRules(rules validate.Rules, leftoverTypes []string) {
+	rules.Add(&RegisterWebhookRequest{},
+		validate.ID("owner_id"),
+		validate.Required("url"),
+		validate.URL("url"),
+		validate.MaxLen("url", 2048),
+		validate.Required("secret"),
+		validate.MaxLen("secret", 255),
+		validate.Required("event_types"),
+		validate.OneOf("event_types", eventTypes...),
+		validate.OneOf("filter.type", leftoverTypes...),
+		validate.MaxLen("filter.city", 255),
+	)
+
+	rules.Add(&WebhookIdentity{}, validate.ID("id"), validate.ID("owner_id"))
+
+	rules.Add(&ListWebhooksRequest{}, validate.ID("owner_id"))
+
+	rules.Add(&ListDeliveriesRequest{},
+		validate.ID("webhook_id"),
+		validate.ID("owner_id"),
+		validate.Between("limit", 0, maxDeliveriesLimit),
+	)
+}
@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Event types a webhook can subscribe to.
+const (
+	EventLeftoverCreated    = "leftover.created"
+	EventLeftoverUpdated    = "leftover.updated"
+	EventLeftoverDeleted    = "leftover.deleted"
+	EventChatSessionStarted = "chat.session_started"
+	EventChatSessionEnded   = "chat.session_ended"
+)
+
+// OwnerOnly tells if events of this type are about the people of a chat,
+// they only go to the webhooks of the leftover owner whatever the filters of the others match.
+func OwnerOnly(name string) bool {
+	return strings.HasPrefix(name, "chat.")
+}
+
+var eventTypes = []string{
+	EventLeftoverCreated,
+	EventLeftoverUpdated,
+	EventLeftoverDeleted,
+	EventChatSessionStarted,
+	EventChatSessionEnded,
+}
+
+// delivery statuses as stored in webhook_delivery.status
+const (
+	statusPending   = "pending"
+	statusDelivered = "delivered"
+	statusDead      = "dead"
+)
+
+const defaultDeliveriesLimit = 50
+
+// ErrNotFound is returned by a WebhookStore when the webhook does not exist.
+var ErrNotFound = errors.New("webhook: not found")
+
+// Event is something that happened to a leftover, Type and City are matched against the webhook filters.
+// Data is marshalled to JSON as the body of the deliveries.
+type Event struct {
+	Name string
+	Type string
+	City string
+	Data any
+}
+
+// Emitter queues deliveries of events for the webhooks that want them, the dispatcher posts them later.
+type Emitter interface {
+	Emit(ctx context.Context, ev Event) error
+	// EmitForLeftover is Emit for callers that only know the leftover, its type and city are looked up.
+	// Chat events only go to the webhooks of the leftover owner.
+	EmitForLeftover(ctx context.Context, name string, leftoverID string, data any) error
+}
+
+// PendingDelivery is a claimed delivery that is due. Deleted is set when its webhook was deleted since.
+type PendingDelivery struct {
+	ID        int64
+	EventType string
+	Payload   json.RawMessage
+	Attempts  int
+	CreatedAt time.Time
+	URL       string
+	Secret    string
+	Deleted   bool
+}
+
+// DeliveryRecorder records the outcome of claimed deliveries, a status code of 0 means there was no answer.
+// Outcomes of deliveries that are no longer pending, e.g. posted again by another dispatcher
+// after the lease ran out, are ignored.
+type DeliveryRecorder interface {
+	MarkDelivered(ctx context.Context, id int64, statusCode int, at time.Time) error
+	MarkRetry(ctx context.Context, id int64, statusCode int, lastError string, next time.Time) error
+	// MarkDead gives up on a delivery, attempted is set when it was posted once more before.
+	MarkDead(ctx context.Context, id int64, attempted bool, statusCode int, lastError string) error
+}
+
+// WebhookStore keeps the webhooks and their deliveries. PostgresWebhookStore is the production store,
+// storage.MemoryStore keeps everything in memory for development.
+type WebhookStore interface {
+	Emitter
+	DeliveryRecorder
+	// Register stores hook, its filter must not be nil.
+	Register(ctx context.Context, hook *Webhook, secret string) error
+	List(ctx context.Context, ownerID string) ([]*Webhook, error)
+	// Delete reports whether a webhook of ownerID was deleted.
+	Delete(ctx context.Context, id string, ownerID string, at time.Time) (bool, error)
+	Owner(ctx context.Context, id string) (string, error)
+	// ListDeliveries lists the newest deliveries first, only those in state unless it is nil.
+	ListDeliveries(ctx context.Context, webhookID string, state *DeliveryStatus, limit int32) ([]*Delivery, error)
+	// ClaimDeliveries leases up to limit due pending deliveries until leaseUntil and returns them.
+	// Other dispatchers skip them meanwhile, those whose outcome is not recorded by then are due again.
+	ClaimDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]PendingDelivery, error)
+}
+
+type WebhookServer struct {
+	UnimplementedWebhookServiceServer
+	store WebhookStore
+}
+
+func NewWebhookServer(store WebhookStore) *WebhookServer {
+	return &WebhookServer{
+		store: store,
+	}
+}
+
+func (s *WebhookServer) RegisterWebhook(ctx context.Context, req *RegisterWebhookRequest) (*Webhook, error) {
+	filter := req.Filter
+	if filter == nil {
+		filter = &WebhookFilter{}
+	}
+
+	hook := &Webhook{
+		Id:         uuid.NewString(),
+		OwnerId:    req.OwnerId,
+		Url:        req.Url,
+		EventTypes: req.EventTypes,
+		Filter:     filter,
+		CreatedAt:  timestamppb.Now(),
+	}
+	if err := s.store.Register(ctx, hook, req.Secret); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to register webhook: %v", err)
+	}
+
+	return hook, nil
+}
+
+func (s *WebhookServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	items, err := s.store.List(ctx, req.OwnerId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query webhooks: %v", err)
+	}
+
+	return &ListWebhooksResponse{Items: items}, nil
+}
+
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, req *WebhookIdentity) (*emptypb.Empty, error) {
+	deleted, err := s.store.Delete(ctx, req.Id, req.OwnerId, time.Now().UTC())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook: %v", err)
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *WebhookServer) ListDeliveries(ctx context.Context, req *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	ownerID, err := s.store.Owner(ctx, req.WebhookId)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "webhook not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get webhook: %v", err)
+	}
+	if ownerID != req.OwnerId {
+		return nil, status.Errorf(codes.PermissionDenied, "only the owner can see the deliveries of a webhook")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+
+	items, err := s.store.ListDeliveries(ctx, req.WebhookId, req.Status, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query deliveries: %v", err)
+	}
+
+	return &ListDeliveriesResponse{Items: items}, nil
+}
+
+func deliveryStatus(s DeliveryStatus) string {
+	switch s {
+	case DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+		return statusDelivered
+	case DeliveryStatus_DELIVERY_STATUS_DEAD:
+		return statusDead
+	default:
+		return statusPending
+	}
+}
+
+func protoStatus(s string) DeliveryStatus {
+	switch s {
+	case statusDelivered:
+		return DeliveryStatus_DELIVERY_STATUS_DELIVERED
+	case statusDead:
+		return DeliveryStatus_DELIVERY_STATUS_DEAD
+	default:
+		return DeliveryStatus_DELIVERY_STATUS_PENDING
+	}
+}
@@ -23,6 +23,8 @@ grpc.web = require('grpc-web');
 var google_protobuf_empty_pb = require('google-protobuf/google/protobuf/empty_pb.js')
 
 var google_protobuf_timestamp_pb = require('google-protobuf/google/protobuf/timestamp_pb.js')
+
+var leftover_leftover_pb = require('../leftover/leftover_pb.js')
 const proto = require('./chat_pb.js');
 
 /**
@@ -250,6 +252,128 @@ proto.ChatServicePromiseClient.prototype.sendMessage =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.EditMessageRequest,
+ *   !proto.google.protobuf.Empty>}
+ */
+const methodDescriptor_ChatService_EditMessage = new grpc.web.MethodDescriptor(
+  '/ChatService/EditMessage',
+  grpc.web.MethodType.UNARY,
+  proto.EditMessageRequest,
+  google_protobuf_empty_pb.Empty,
+  /**
+   * @param {!proto.EditMessageRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  google_protobuf_empty_pb.Empty.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.EditMessageRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.google.protobuf.Empty)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.google.protobuf.Empty>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.editMessage =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/EditMessage',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_EditMessage,
+      callback);
+};
+
+
+/**
+ * @param {!proto.EditMessageRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.google.protobuf.Empty>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.editMessage =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/EditMessage',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_EditMessage);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.DeleteMessageRequest,
+ *   !proto.google.protobuf.Empty>}
+ */
+const methodDescriptor_ChatService_DeleteMessage = new grpc.web.MethodDescriptor(
+  '/ChatService/DeleteMessage',
+  grpc.web.MethodType.UNARY,
+  proto.DeleteMessageRequest,
+  google_protobuf_empty_pb.Empty,
+  /**
+   * @param {!proto.DeleteMessageRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  google_protobuf_empty_pb.Empty.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.DeleteMessageRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.google.protobuf.Empty)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.google.protobuf.Empty>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.deleteMessage =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/DeleteMessage',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_DeleteMessage,
+      callback);
+};
+
+
+/**
+ * @param {!proto.DeleteMessageRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.google.protobuf.Empty>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.deleteMessage =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/DeleteMessage',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_DeleteMessage);
+};
+
+
 /**
  * @const
  * @type {!grpc.web.MethodDescriptor<
@@ -311,5 +435,427 @@ proto.ChatServicePromiseClient.prototype.endChatSession =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.BlockUserRequest,
+ *   !proto.google.protobuf.Empty>}
+ */
+const methodDescriptor_ChatService_BlockUser = new grpc.web.MethodDescriptor(
+  '/ChatService/BlockUser',
+  grpc.web.MethodType.UNARY,
+  proto.BlockUserRequest,
+  google_protobuf_empty_pb.Empty,
+  /**
+   * @param {!proto.BlockUserRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  google_protobuf_empty_pb.Empty.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.BlockUserRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.google.protobuf.Empty)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.google.protobuf.Empty>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.blockUser =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/BlockUser',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_BlockUser,
+      callback);
+};
+
+
+/**
+ * @param {!proto.BlockUserRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.google.protobuf.Empty>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.blockUser =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/BlockUser',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_BlockUser);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.BlockUserRequest,
+ *   !proto.google.protobuf.Empty>}
+ */
+const methodDescriptor_ChatService_UnblockUser = new grpc.web.MethodDescriptor(
+  '/ChatService/UnblockUser',
+  grpc.web.MethodType.UNARY,
+  proto.BlockUserRequest,
+  google_protobuf_empty_pb.Empty,
+  /**
+   * @param {!proto.BlockUserRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  google_protobuf_empty_pb.Empty.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.BlockUserRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.google.protobuf.Empty)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.google.protobuf.Empty>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.unblockUser =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/UnblockUser',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_UnblockUser,
+      callback);
+};
+
+
+/**
+ * @param {!proto.BlockUserRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.google.protobuf.Empty>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.unblockUser =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/UnblockUser',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_UnblockUser);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.ListBlockedRequest,
+ *   !proto.ListBlockedResponse>}
+ */
+const methodDescriptor_ChatService_ListBlocked = new grpc.web.MethodDescriptor(
+  '/ChatService/ListBlocked',
+  grpc.web.MethodType.UNARY,
+  proto.ListBlockedRequest,
+  proto.ListBlockedResponse,
+  /**
+   * @param {!proto.ListBlockedRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.ListBlockedResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.ListBlockedRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.ListBlockedResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.ListBlockedResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.listBlocked =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/ListBlocked',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_ListBlocked,
+      callback);
+};
+
+
+/**
+ * @param {!proto.ListBlockedRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.ListBlockedResponse>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.listBlocked =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/ListBlocked',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_ListBlocked);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.BanUserRequest,
+ *   !proto.google.protobuf.Empty>}
+ */
+const methodDescriptor_ChatService_BanUser = new grpc.web.MethodDescriptor(
+  '/ChatService/BanUser',
+  grpc.web.MethodType.UNARY,
+  proto.BanUserRequest,
+  google_protobuf_empty_pb.Empty,
+  /**
+   * @param {!proto.BanUserRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  google_protobuf_empty_pb.Empty.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.BanUserRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.google.protobuf.Empty)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.google.protobuf.Empty>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.banUser =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/BanUser',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_BanUser,
+      callback);
+};
+
+
+/**
+ * @param {!proto.BanUserRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.google.protobuf.Empty>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.banUser =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/BanUser',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_BanUser);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.PickupResponseRequest,
+ *   !proto.google.protobuf.Empty>}
+ */
+const methodDescriptor_ChatService_RespondToPickup = new grpc.web.MethodDescriptor(
+  '/ChatService/RespondToPickup',
+  grpc.web.MethodType.UNARY,
+  proto.PickupResponseRequest,
+  google_protobuf_empty_pb.Empty,
+  /**
+   * @param {!proto.PickupResponseRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  google_protobuf_empty_pb.Empty.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.PickupResponseRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.google.protobuf.Empty)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.google.protobuf.Empty>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.respondToPickup =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/RespondToPickup',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_RespondToPickup,
+      callback);
+};
+
+
+/**
+ * @param {!proto.PickupResponseRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.google.protobuf.Empty>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.respondToPickup =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/RespondToPickup',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_RespondToPickup);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.AgreedPickupRequest,
+ *   !proto.AgreedPickup>}
+ */
+const methodDescriptor_ChatService_GetAgreedPickup = new grpc.web.MethodDescriptor(
+  '/ChatService/GetAgreedPickup',
+  grpc.web.MethodType.UNARY,
+  proto.AgreedPickupRequest,
+  proto.AgreedPickup,
+  /**
+   * @param {!proto.AgreedPickupRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.AgreedPickup.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.AgreedPickupRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.AgreedPickup)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.AgreedPickup>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.getAgreedPickup =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/ChatService/GetAgreedPickup',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_GetAgreedPickup,
+      callback);
+};
+
+
+/**
+ * @param {!proto.AgreedPickupRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.AgreedPickup>}
+ *     Promise that resolves to the response
+ */
+proto.ChatServicePromiseClient.prototype.getAgreedPickup =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/ChatService/GetAgreedPickup',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_GetAgreedPickup);
+};
+
+
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.ExportTranscriptRequest,
+ *   !proto.TranscriptLine>}
+ */
+const methodDescriptor_ChatService_ExportTranscript = new grpc.web.MethodDescriptor(
+  '/ChatService/ExportTranscript',
+  grpc.web.MethodType.SERVER_STREAMING,
+  proto.ExportTranscriptRequest,
+  proto.TranscriptLine,
+  /**
+   * @param {!proto.ExportTranscriptRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.TranscriptLine.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.ExportTranscriptRequest} request The request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.TranscriptLine>}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServiceClient.prototype.exportTranscript =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/ChatService/ExportTranscript',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_ExportTranscript);
+};
+
+
+/**
+ * @param {!proto.ExportTranscriptRequest} request The request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!grpc.web.ClientReadableStream<!proto.TranscriptLine>}
+ *     The XHR Node Readable Stream
+ */
+proto.ChatServicePromiseClient.prototype.exportTranscript =
+    function(request, metadata) {
+  return this.client_.serverStreaming(this.hostname_ +
+      '/ChatService/ExportTranscript',
+      request,
+      metadata || {},
+      methodDescriptor_ChatService_ExportTranscript);
+};
+
+
 module.exports = proto;
 
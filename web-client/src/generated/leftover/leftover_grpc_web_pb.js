@@ -200,16 +200,16 @@ proto.LeftoverServicePromiseClient.prototype.getLeftover =
 /**
  * @const
  * @type {!grpc.web.MethodDescriptor<
- *   !proto.LeftoverRequest,
+ *   !proto.LeftoverQuery,
  *   !proto.LeftoverResponse>}
  */
 const methodDescriptor_LeftoverService_GetLeftovers = new grpc.web.MethodDescriptor(
   '/LeftoverService/GetLeftovers',
   grpc.web.MethodType.UNARY,
-  proto.LeftoverRequest,
+  proto.LeftoverQuery,
   proto.LeftoverResponse,
   /**
-   * @param {!proto.LeftoverRequest} request
+   * @param {!proto.LeftoverQuery} request
    * @return {!Uint8Array}
    */
   function(request) {
@@ -220,7 +220,7 @@ const methodDescriptor_LeftoverService_GetLeftovers = new grpc.web.MethodDescrip
 
 
 /**
- * @param {!proto.LeftoverRequest} request The
+ * @param {!proto.LeftoverQuery} request The
  *     request proto
  * @param {?Object<string, string>} metadata User defined
  *     call metadata
@@ -241,7 +241,7 @@ proto.LeftoverServiceClient.prototype.getLeftovers =
 
 
 /**
- * @param {!proto.LeftoverRequest} request The
+ * @param {!proto.LeftoverQuery} request The
  *     request proto
  * @param {?Object<string, string>=} metadata User defined
  *     call metadata
@@ -14,12 +14,78 @@ var global = Function('return this')();
 
 var google_protobuf_empty_pb = require('google-protobuf/google/protobuf/empty_pb.js');
 goog.object.extend(proto, google_protobuf_empty_pb);
+goog.exportSymbol('proto.Address', null, global);
+goog.exportSymbol('proto.BoundingBox', null, global);
 goog.exportSymbol('proto.DeleteRequest', null, global);
 goog.exportSymbol('proto.Leftover', null, global);
 goog.exportSymbol('proto.LeftoverIdentity', null, global);
 goog.exportSymbol('proto.LeftoverQuery', null, global);
 goog.exportSymbol('proto.LeftoverRequest', null, global);
 goog.exportSymbol('proto.LeftoverResponse', null, global);
+goog.exportSymbol('proto.Point', null, global);
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.Point = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.Point, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.Point.displayName = 'proto.Point';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.Address = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.Address, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.Address.displayName = 'proto.Address';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.BoundingBox = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.BoundingBox, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.BoundingBox.displayName = 'proto.BoundingBox';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -105,47 +171,707 @@ if (goog.DEBUG && !COMPILED) {
   proto.LeftoverIdentity.displayName = 'proto.LeftoverIdentity';
 }
 /**
- * Generated by JsPbCodeGenerator.
- * @param {Array=} opt_data Optional initial data array, typically from a
- * server response, or constructed directly in Javascript. The array is used
- * in place and becomes part of the constructed object. It is not cloned.
- * If no data is provided, the constructed object will be empty, but still
- * valid.
- * @extends {jspb.Message}
- * @constructor
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.DeleteRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.DeleteRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.DeleteRequest.displayName = 'proto.DeleteRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.LeftoverQuery = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.LeftoverQuery, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.LeftoverQuery.displayName = 'proto.LeftoverQuery';
+}
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.Point.prototype.toObject = function(opt_includeInstance) {
+  return proto.Point.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.Point} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.Point.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    longitude: jspb.Message.getFloatingPointFieldWithDefault(msg, 1, 0.0),
+    latitude: jspb.Message.getFloatingPointFieldWithDefault(msg, 2, 0.0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.Point}
+ */
+proto.Point.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.Point;
+  return proto.Point.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.Point} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.Point}
+ */
+proto.Point.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readDouble());
+      msg.setLongitude(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readDouble());
+      msg.setLatitude(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.Point.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.Point.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.Point} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.Point.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getLongitude();
+  if (f !== 0.0) {
+    writer.writeDouble(
+      1,
+      f
+    );
+  }
+  f = message.getLatitude();
+  if (f !== 0.0) {
+    writer.writeDouble(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional double longitude = 1;
+ * @return {number}
+ */
+proto.Point.prototype.getLongitude = function() {
+  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 1, 0.0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.Point} returns this
+ */
+proto.Point.prototype.setLongitude = function(value) {
+  return jspb.Message.setProto3FloatField(this, 1, value);
+};
+
+
+/**
+ * optional double latitude = 2;
+ * @return {number}
+ */
+proto.Point.prototype.getLatitude = function() {
+  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 2, 0.0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.Point} returns this
+ */
+proto.Point.prototype.setLatitude = function(value) {
+  return jspb.Message.setProto3FloatField(this, 2, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.Address.prototype.toObject = function(opt_includeInstance) {
+  return proto.Address.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.Address} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.Address.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    street: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    district: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    city: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    province: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    state: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    country: jspb.Message.getFieldWithDefault(msg, 6, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.Address}
+ */
+proto.Address.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.Address;
+  return proto.Address.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.Address} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.Address}
+ */
+proto.Address.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setStreet(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDistrict(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setCity(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setProvince(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setState(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setCountry(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.Address.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.Address.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.Address} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.Address.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getStreet();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getDistrict();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getCity();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getProvince();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = /** @type {string} */ (jspb.Message.getField(message, 5));
+  if (f != null) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+  f = message.getCountry();
+  if (f.length > 0) {
+    writer.writeString(
+      6,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string street = 1;
+ * @return {string}
+ */
+proto.Address.prototype.getStreet = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.Address} returns this
+ */
+proto.Address.prototype.setStreet = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string district = 2;
+ * @return {string}
+ */
+proto.Address.prototype.getDistrict = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.Address} returns this
+ */
+proto.Address.prototype.setDistrict = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string city = 3;
+ * @return {string}
+ */
+proto.Address.prototype.getCity = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.Address} returns this
+ */
+proto.Address.prototype.setCity = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string province = 4;
+ * @return {string}
+ */
+proto.Address.prototype.getProvince = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.Address} returns this
+ */
+proto.Address.prototype.setProvince = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
+/**
+ * optional string state = 5;
+ * @return {string}
+ */
+proto.Address.prototype.getState = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.Address} returns this
+ */
+proto.Address.prototype.setState = function(value) {
+  return jspb.Message.setField(this, 5, value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.Address} returns this
+ */
+proto.Address.prototype.clearState = function() {
+  return jspb.Message.setField(this, 5, undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.Address.prototype.hasState = function() {
+  return jspb.Message.getField(this, 5) != null;
+};
+
+
+/**
+ * optional string country = 6;
+ * @return {string}
+ */
+proto.Address.prototype.getCountry = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.Address} returns this
+ */
+proto.Address.prototype.setCountry = function(value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.BoundingBox.prototype.toObject = function(opt_includeInstance) {
+  return proto.BoundingBox.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.BoundingBox} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.BoundingBox.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    topLeft: (f = msg.getTopLeft()) && proto.Point.toObject(includeInstance, f),
+    bottomRight: (f = msg.getBottomRight()) && proto.Point.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.BoundingBox}
+ */
+proto.BoundingBox.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.BoundingBox;
+  return proto.BoundingBox.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.BoundingBox} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.BoundingBox}
+ */
+proto.BoundingBox.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.Point;
+      reader.readMessage(value,proto.Point.deserializeBinaryFromReader);
+      msg.setTopLeft(value);
+      break;
+    case 2:
+      var value = new proto.Point;
+      reader.readMessage(value,proto.Point.deserializeBinaryFromReader);
+      msg.setBottomRight(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.BoundingBox.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.BoundingBox.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.BoundingBox} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.BoundingBox.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getTopLeft();
+  if (f != null) {
+    writer.writeMessage(
+      1,
+      f,
+      proto.Point.serializeBinaryToWriter
+    );
+  }
+  f = message.getBottomRight();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      proto.Point.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional Point top_left = 1;
+ * @return {?proto.Point}
+ */
+proto.BoundingBox.prototype.getTopLeft = function() {
+  return /** @type{?proto.Point} */ (
+    jspb.Message.getWrapperField(this, proto.Point, 1));
+};
+
+
+/**
+ * @param {?proto.Point|undefined} value
+ * @return {!proto.BoundingBox} returns this
+*/
+proto.BoundingBox.prototype.setTopLeft = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.BoundingBox} returns this
+ */
+proto.BoundingBox.prototype.clearTopLeft = function() {
+  return this.setTopLeft(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.BoundingBox.prototype.hasTopLeft = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * optional Point bottom_right = 2;
+ * @return {?proto.Point}
+ */
+proto.BoundingBox.prototype.getBottomRight = function() {
+  return /** @type{?proto.Point} */ (
+    jspb.Message.getWrapperField(this, proto.Point, 2));
+};
+
+
+/**
+ * @param {?proto.Point|undefined} value
+ * @return {!proto.BoundingBox} returns this
+*/
+proto.BoundingBox.prototype.setBottomRight = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.BoundingBox} returns this
  */
-proto.DeleteRequest = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+proto.BoundingBox.prototype.clearBottomRight = function() {
+  return this.setBottomRight(undefined);
 };
-goog.inherits(proto.DeleteRequest, jspb.Message);
-if (goog.DEBUG && !COMPILED) {
-  /**
-   * @public
-   * @override
-   */
-  proto.DeleteRequest.displayName = 'proto.DeleteRequest';
-}
+
+
 /**
- * Generated by JsPbCodeGenerator.
- * @param {Array=} opt_data Optional initial data array, typically from a
- * server response, or constructed directly in Javascript. The array is used
- * in place and becomes part of the constructed object. It is not cloned.
- * If no data is provided, the constructed object will be empty, but still
- * valid.
- * @extends {jspb.Message}
- * @constructor
+ * Returns whether this field is set.
+ * @return {boolean}
  */
-proto.LeftoverQuery = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+proto.BoundingBox.prototype.hasBottomRight = function() {
+  return jspb.Message.getField(this, 2) != null;
 };
-goog.inherits(proto.LeftoverQuery, jspb.Message);
-if (goog.DEBUG && !COMPILED) {
-  /**
-   * @public
-   * @override
-   */
-  proto.LeftoverQuery.displayName = 'proto.LeftoverQuery';
-}
+
+
 
 
 
@@ -182,9 +908,10 @@ proto.Leftover.toObject = function(includeInstance, msg) {
     ownerId: jspb.Message.getFieldWithDefault(msg, 2, ""),
     name: jspb.Message.getFieldWithDefault(msg, 3, ""),
     description: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    image: jspb.Message.getFieldWithDefault(msg, 5, ""),
-    longitude: jspb.Message.getFloatingPointFieldWithDefault(msg, 6, 0.0),
-    latitude: jspb.Message.getFloatingPointFieldWithDefault(msg, 7, 0.0)
+    type: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    imageurl: jspb.Message.getFieldWithDefault(msg, 6, ""),
+    coordiantes: (f = msg.getCoordiantes()) && proto.Point.toObject(includeInstance, f),
+    address: (f = msg.getAddress()) && proto.Address.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -239,15 +966,21 @@ proto.Leftover.deserializeBinaryFromReader = function(msg, reader) {
       break;
     case 5:
       var value = /** @type {string} */ (reader.readString());
-      msg.setImage(value);
+      msg.setType(value);
       break;
     case 6:
-      var value = /** @type {number} */ (reader.readFloat());
-      msg.setLongitude(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setImageurl(value);
       break;
     case 7:
-      var value = /** @type {number} */ (reader.readFloat());
-      msg.setLatitude(value);
+      var value = new proto.Point;
+      reader.readMessage(value,proto.Point.deserializeBinaryFromReader);
+      msg.setCoordiantes(value);
+      break;
+    case 8:
+      var value = new proto.Address;
+      reader.readMessage(value,proto.Address.deserializeBinaryFromReader);
+      msg.setAddress(value);
       break;
     default:
       reader.skipField();
@@ -306,25 +1039,34 @@ proto.Leftover.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
-  f = message.getImage();
+  f = message.getType();
   if (f.length > 0) {
     writer.writeString(
       5,
       f
     );
   }
-  f = message.getLongitude();
-  if (f !== 0.0) {
-    writer.writeFloat(
+  f = message.getImageurl();
+  if (f.length > 0) {
+    writer.writeString(
       6,
       f
     );
   }
-  f = message.getLatitude();
-  if (f !== 0.0) {
-    writer.writeFloat(
+  f = message.getCoordiantes();
+  if (f != null) {
+    writer.writeMessage(
       7,
-      f
+      f,
+      proto.Point.serializeBinaryToWriter
+    );
+  }
+  f = message.getAddress();
+  if (f != null) {
+    writer.writeMessage(
+      8,
+      f,
+      proto.Address.serializeBinaryToWriter
     );
   }
 };
@@ -403,10 +1145,10 @@ proto.Leftover.prototype.setDescription = function(value) {
 
 
 /**
- * optional string image = 5;
+ * optional string type = 5;
  * @return {string}
  */
-proto.Leftover.prototype.getImage = function() {
+proto.Leftover.prototype.getType = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
 };
 
@@ -415,44 +1157,100 @@ proto.Leftover.prototype.getImage = function() {
  * @param {string} value
  * @return {!proto.Leftover} returns this
  */
-proto.Leftover.prototype.setImage = function(value) {
+proto.Leftover.prototype.setType = function(value) {
   return jspb.Message.setProto3StringField(this, 5, value);
 };
 
 
 /**
- * optional float longitude = 6;
- * @return {number}
+ * optional string imageUrl = 6;
+ * @return {string}
  */
-proto.Leftover.prototype.getLongitude = function() {
-  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 6, 0.0));
+proto.Leftover.prototype.getImageurl = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
 };
 
 
 /**
- * @param {number} value
+ * @param {string} value
  * @return {!proto.Leftover} returns this
  */
-proto.Leftover.prototype.setLongitude = function(value) {
-  return jspb.Message.setProto3FloatField(this, 6, value);
+proto.Leftover.prototype.setImageurl = function(value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
 };
 
 
 /**
- * optional float latitude = 7;
- * @return {number}
+ * optional Point coordiantes = 7;
+ * @return {?proto.Point}
  */
-proto.Leftover.prototype.getLatitude = function() {
-  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 7, 0.0));
+proto.Leftover.prototype.getCoordiantes = function() {
+  return /** @type{?proto.Point} */ (
+    jspb.Message.getWrapperField(this, proto.Point, 7));
 };
 
 
 /**
- * @param {number} value
+ * @param {?proto.Point|undefined} value
+ * @return {!proto.Leftover} returns this
+*/
+proto.Leftover.prototype.setCoordiantes = function(value) {
+  return jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.Leftover} returns this
+ */
+proto.Leftover.prototype.clearCoordiantes = function() {
+  return this.setCoordiantes(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.Leftover.prototype.hasCoordiantes = function() {
+  return jspb.Message.getField(this, 7) != null;
+};
+
+
+/**
+ * optional Address address = 8;
+ * @return {?proto.Address}
+ */
+proto.Leftover.prototype.getAddress = function() {
+  return /** @type{?proto.Address} */ (
+    jspb.Message.getWrapperField(this, proto.Address, 8));
+};
+
+
+/**
+ * @param {?proto.Address|undefined} value
+ * @return {!proto.Leftover} returns this
+*/
+proto.Leftover.prototype.setAddress = function(value) {
+  return jspb.Message.setWrapperField(this, 8, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
  * @return {!proto.Leftover} returns this
  */
-proto.Leftover.prototype.setLatitude = function(value) {
-  return jspb.Message.setProto3FloatField(this, 7, value);
+proto.Leftover.prototype.clearAddress = function() {
+  return this.setAddress(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.Leftover.prototype.hasAddress = function() {
+  return jspb.Message.getField(this, 8) != null;
 };
 
 
@@ -491,9 +1289,10 @@ proto.LeftoverRequest.toObject = function(includeInstance, msg) {
     ownerId: jspb.Message.getFieldWithDefault(msg, 1, ""),
     name: jspb.Message.getFieldWithDefault(msg, 2, ""),
     description: jspb.Message.getFieldWithDefault(msg, 3, ""),
-    image: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    longitude: jspb.Message.getFloatingPointFieldWithDefault(msg, 5, 0.0),
-    latitude: jspb.Message.getFloatingPointFieldWithDefault(msg, 6, 0.0)
+    type: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    imageurl: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    coordinates: (f = msg.getCoordinates()) && proto.Point.toObject(includeInstance, f),
+    address: (f = msg.getAddress()) && proto.Address.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -544,15 +1343,21 @@ proto.LeftoverRequest.deserializeBinaryFromReader = function(msg, reader) {
       break;
     case 4:
       var value = /** @type {string} */ (reader.readString());
-      msg.setImage(value);
+      msg.setType(value);
       break;
     case 5:
-      var value = /** @type {number} */ (reader.readFloat());
-      msg.setLongitude(value);
+      var value = /** @type {string} */ (reader.readString());
+      msg.setImageurl(value);
       break;
     case 6:
-      var value = /** @type {number} */ (reader.readFloat());
-      msg.setLatitude(value);
+      var value = new proto.Point;
+      reader.readMessage(value,proto.Point.deserializeBinaryFromReader);
+      msg.setCoordinates(value);
+      break;
+    case 7:
+      var value = new proto.Address;
+      reader.readMessage(value,proto.Address.deserializeBinaryFromReader);
+      msg.setAddress(value);
       break;
     default:
       reader.skipField();
@@ -604,25 +1409,34 @@ proto.LeftoverRequest.serializeBinaryToWriter = function(message, writer) {
       f
     );
   }
-  f = message.getImage();
+  f = message.getType();
   if (f.length > 0) {
     writer.writeString(
       4,
       f
     );
   }
-  f = message.getLongitude();
-  if (f !== 0.0) {
-    writer.writeFloat(
+  f = message.getImageurl();
+  if (f.length > 0) {
+    writer.writeString(
       5,
       f
     );
   }
-  f = message.getLatitude();
-  if (f !== 0.0) {
-    writer.writeFloat(
+  f = message.getCoordinates();
+  if (f != null) {
+    writer.writeMessage(
       6,
-      f
+      f,
+      proto.Point.serializeBinaryToWriter
+    );
+  }
+  f = message.getAddress();
+  if (f != null) {
+    writer.writeMessage(
+      7,
+      f,
+      proto.Address.serializeBinaryToWriter
     );
   }
 };
@@ -683,10 +1497,10 @@ proto.LeftoverRequest.prototype.setDescription = function(value) {
 
 
 /**
- * optional string image = 4;
+ * optional string type = 4;
  * @return {string}
  */
-proto.LeftoverRequest.prototype.getImage = function() {
+proto.LeftoverRequest.prototype.getType = function() {
   return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
 };
 
@@ -695,44 +1509,100 @@ proto.LeftoverRequest.prototype.getImage = function() {
  * @param {string} value
  * @return {!proto.LeftoverRequest} returns this
  */
-proto.LeftoverRequest.prototype.setImage = function(value) {
+proto.LeftoverRequest.prototype.setType = function(value) {
   return jspb.Message.setProto3StringField(this, 4, value);
 };
 
 
 /**
- * optional float longitude = 5;
- * @return {number}
+ * optional string imageUrl = 5;
+ * @return {string}
  */
-proto.LeftoverRequest.prototype.getLongitude = function() {
-  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 5, 0.0));
+proto.LeftoverRequest.prototype.getImageurl = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
 };
 
 
 /**
- * @param {number} value
+ * @param {string} value
  * @return {!proto.LeftoverRequest} returns this
  */
-proto.LeftoverRequest.prototype.setLongitude = function(value) {
-  return jspb.Message.setProto3FloatField(this, 5, value);
+proto.LeftoverRequest.prototype.setImageurl = function(value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
 };
 
 
 /**
- * optional float latitude = 6;
- * @return {number}
+ * optional Point coordinates = 6;
+ * @return {?proto.Point}
  */
-proto.LeftoverRequest.prototype.getLatitude = function() {
-  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 6, 0.0));
+proto.LeftoverRequest.prototype.getCoordinates = function() {
+  return /** @type{?proto.Point} */ (
+    jspb.Message.getWrapperField(this, proto.Point, 6));
 };
 
 
 /**
- * @param {number} value
+ * @param {?proto.Point|undefined} value
+ * @return {!proto.LeftoverRequest} returns this
+*/
+proto.LeftoverRequest.prototype.setCoordinates = function(value) {
+  return jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.LeftoverRequest} returns this
+ */
+proto.LeftoverRequest.prototype.clearCoordinates = function() {
+  return this.setCoordinates(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.LeftoverRequest.prototype.hasCoordinates = function() {
+  return jspb.Message.getField(this, 6) != null;
+};
+
+
+/**
+ * optional Address address = 7;
+ * @return {?proto.Address}
+ */
+proto.LeftoverRequest.prototype.getAddress = function() {
+  return /** @type{?proto.Address} */ (
+    jspb.Message.getWrapperField(this, proto.Address, 7));
+};
+
+
+/**
+ * @param {?proto.Address|undefined} value
+ * @return {!proto.LeftoverRequest} returns this
+*/
+proto.LeftoverRequest.prototype.setAddress = function(value) {
+  return jspb.Message.setWrapperField(this, 7, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
  * @return {!proto.LeftoverRequest} returns this
  */
-proto.LeftoverRequest.prototype.setLatitude = function(value) {
-  return jspb.Message.setProto3FloatField(this, 6, value);
+proto.LeftoverRequest.prototype.clearAddress = function() {
+  return this.setAddress(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.LeftoverRequest.prototype.hasAddress = function() {
+  return jspb.Message.getField(this, 7) != null;
 };
 
 
@@ -1220,7 +2090,9 @@ proto.LeftoverQuery.toObject = function(includeInstance, msg) {
   var f, obj = {
     id: jspb.Message.getFieldWithDefault(msg, 1, ""),
     name: jspb.Message.getFieldWithDefault(msg, 2, ""),
-    ownerId: jspb.Message.getFieldWithDefault(msg, 3, "")
+    ownerId: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    type: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    bbox: (f = msg.getBbox()) && proto.BoundingBox.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1269,6 +2141,15 @@ proto.LeftoverQuery.deserializeBinaryFromReader = function(msg, reader) {
       var value = /** @type {string} */ (reader.readString());
       msg.setOwnerId(value);
       break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setType(value);
+      break;
+    case 5:
+      var value = new proto.BoundingBox;
+      reader.readMessage(value,proto.BoundingBox.deserializeBinaryFromReader);
+      msg.setBbox(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1298,27 +2179,42 @@ proto.LeftoverQuery.prototype.serializeBinary = function() {
  */
 proto.LeftoverQuery.serializeBinaryToWriter = function(message, writer) {
   var f = undefined;
-  f = message.getId();
-  if (f.length > 0) {
+  f = /** @type {string} */ (jspb.Message.getField(message, 1));
+  if (f != null) {
     writer.writeString(
       1,
       f
     );
   }
-  f = message.getName();
-  if (f.length > 0) {
+  f = /** @type {string} */ (jspb.Message.getField(message, 2));
+  if (f != null) {
     writer.writeString(
       2,
       f
     );
   }
-  f = message.getOwnerId();
-  if (f.length > 0) {
+  f = /** @type {string} */ (jspb.Message.getField(message, 3));
+  if (f != null) {
     writer.writeString(
       3,
       f
     );
   }
+  f = /** @type {string} */ (jspb.Message.getField(message, 4));
+  if (f != null) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getBbox();
+  if (f != null) {
+    writer.writeMessage(
+      5,
+      f,
+      proto.BoundingBox.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -1336,7 +2232,25 @@ proto.LeftoverQuery.prototype.getId = function() {
  * @return {!proto.LeftoverQuery} returns this
  */
 proto.LeftoverQuery.prototype.setId = function(value) {
-  return jspb.Message.setProto3StringField(this, 1, value);
+  return jspb.Message.setField(this, 1, value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.LeftoverQuery} returns this
+ */
+proto.LeftoverQuery.prototype.clearId = function() {
+  return jspb.Message.setField(this, 1, undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.LeftoverQuery.prototype.hasId = function() {
+  return jspb.Message.getField(this, 1) != null;
 };
 
 
@@ -1354,7 +2268,25 @@ proto.LeftoverQuery.prototype.getName = function() {
  * @return {!proto.LeftoverQuery} returns this
  */
 proto.LeftoverQuery.prototype.setName = function(value) {
-  return jspb.Message.setProto3StringField(this, 2, value);
+  return jspb.Message.setField(this, 2, value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.LeftoverQuery} returns this
+ */
+proto.LeftoverQuery.prototype.clearName = function() {
+  return jspb.Message.setField(this, 2, undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.LeftoverQuery.prototype.hasName = function() {
+  return jspb.Message.getField(this, 2) != null;
 };
 
 
@@ -1372,7 +2304,98 @@ proto.LeftoverQuery.prototype.getOwnerId = function() {
  * @return {!proto.LeftoverQuery} returns this
  */
 proto.LeftoverQuery.prototype.setOwnerId = function(value) {
-  return jspb.Message.setProto3StringField(this, 3, value);
+  return jspb.Message.setField(this, 3, value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.LeftoverQuery} returns this
+ */
+proto.LeftoverQuery.prototype.clearOwnerId = function() {
+  return jspb.Message.setField(this, 3, undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.LeftoverQuery.prototype.hasOwnerId = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * optional string type = 4;
+ * @return {string}
+ */
+proto.LeftoverQuery.prototype.getType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.LeftoverQuery} returns this
+ */
+proto.LeftoverQuery.prototype.setType = function(value) {
+  return jspb.Message.setField(this, 4, value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.LeftoverQuery} returns this
+ */
+proto.LeftoverQuery.prototype.clearType = function() {
+  return jspb.Message.setField(this, 4, undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.LeftoverQuery.prototype.hasType = function() {
+  return jspb.Message.getField(this, 4) != null;
+};
+
+
+/**
+ * optional BoundingBox bbox = 5;
+ * @return {?proto.BoundingBox}
+ */
+proto.LeftoverQuery.prototype.getBbox = function() {
+  return /** @type{?proto.BoundingBox} */ (
+    jspb.Message.getWrapperField(this, proto.BoundingBox, 5));
+};
+
+
+/**
+ * @param {?proto.BoundingBox|undefined} value
+ * @return {!proto.LeftoverQuery} returns this
+*/
+proto.LeftoverQuery.prototype.setBbox = function(value) {
+  return jspb.Message.setWrapperField(this, 5, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.LeftoverQuery} returns this
+ */
+proto.LeftoverQuery.prototype.clearBbox = function() {
+  return this.setBbox(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.LeftoverQuery.prototype.hasBbox = function() {
+  return jspb.Message.getField(this, 5) != null;
 };
 
 